@@ -0,0 +1,78 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const defaultAcquireInterval = 30 * time.Second
+
+// AcquirePolicy configures AcquireWhenAvailable.
+type AcquirePolicy struct {
+	// Interval is the delay between attempts. Default: 30 seconds. Ignored
+	// when Backoff is set.
+	Interval time.Duration
+
+	// Backoff, when set, makes the delay between attempts grow with jittered
+	// exponential backoff instead of using a fixed Interval.
+	Backoff *Backoff
+
+	// OnProgress is invoked after every attempt that found no free seat. It
+	// may be nil.
+	OnProgress func(progress AcquireProgress)
+}
+
+// AcquireProgress reports the progress of AcquireWhenAvailable.
+type AcquireProgress struct {
+	// Attempts is the number of lease requests sent so far.
+	Attempts int
+
+	// Waited is the time elapsed since the first attempt.
+	Waited time.Duration
+
+	// Next is the delay before the next attempt.
+	Next time.Duration
+}
+
+func (policy AcquirePolicy) delay(retry int) time.Duration {
+	if policy.Backoff != nil {
+		return policy.Backoff.Delay(retry)
+	}
+	if policy.Interval <= 0 {
+		return defaultAcquireInterval
+	}
+	return policy.Interval
+}
+
+// AcquireWhenAvailable requests the lease and, while the LexFloatServer reports
+// LF_E_LICENSE_LIMIT_REACHED, keeps requesting it until a seat becomes free or
+// ctx is done.
+//
+// Parameters:
+// - ctx: bounds the total time spent waiting for a seat.
+// - policy: delay between attempts and progress callback.
+//
+// Errors: ctx.Err() or see RequestFloatingLicense
+func AcquireWhenAvailable(ctx context.Context, policy AcquirePolicy) error {
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		err := RequestFloatingLicenseContext(ctx)
+		if !errors.Is(err, ErrLicenseLimitReached) {
+			return opError("RequestFloatingLicense", err)
+		}
+		next := policy.delay(attempt)
+		if policy.OnProgress != nil {
+			policy.OnProgress(AcquireProgress{
+				Attempts: attempt + 1,
+				Waited:   time.Since(start),
+				Next:     next,
+			})
+		}
+		if err := sleepContext(ctx, next); err != nil {
+			return err
+		}
+	}
+}
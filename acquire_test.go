@@ -0,0 +1,145 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// seatHolder returns another client of server that holds a lease.
+func seatHolder(t *testing.T, server *lexfloattest.Server) *lexfloattest.Backend {
+	t.Helper()
+	holder := server.NewBackend()
+	holder.SetHostProductId("product")
+	holder.SetHostUrl("http://localhost:8090")
+	holder.SetFloatingLicenseCallback(func(int) {})
+	if status := holder.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("seat holder: RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	return holder
+}
+
+func TestAcquireWhenAvailable(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{LicenseLimit: 1})
+	useBackend(t, server, server.NewBackend())
+	holder := seatHolder(t, server)
+	var progress []lexfloatclient.AcquireProgress
+	err := lexfloatclient.AcquireWhenAvailable(context.Background(), lexfloatclient.AcquirePolicy{
+		Interval: time.Millisecond,
+		OnProgress: func(p lexfloatclient.AcquireProgress) {
+			progress = append(progress, p)
+			// The seat is freed after the third failed attempt.
+			if p.Attempts == 3 {
+				holder.DropFloatingLicense()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("AcquireWhenAvailable: %v", err)
+	}
+	if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != 5 {
+		t.Errorf("lease requests = %d, want 5", got)
+	}
+	if len(progress) != 3 {
+		t.Fatalf("OnProgress called %d times, want 3", len(progress))
+	}
+	for i, p := range progress {
+		if p.Attempts != i+1 || p.Next != time.Millisecond {
+			t.Errorf("progress %d = %+v, want attempt %d with a delay of 1ms", i, p, i+1)
+		}
+		if i > 0 && p.Waited < progress[i-1].Waited+time.Millisecond {
+			t.Errorf("progress %d: Waited = %v, want at least 1ms more than %v", i, p.Waited, progress[i-1].Waited)
+		}
+	}
+	if lexfloatclient.HasFloatingLicense() != lexfloatclient.LF_OK {
+		t.Error("HasFloatingLicense != LF_OK after AcquireWhenAvailable")
+	}
+}
+
+func TestAcquireWhenAvailableBackoff(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	server.AddFault(lexfloattest.Fault{Op: lexfloattest.OpRequestFloatingLicense, Status: lexfloatclient.LF_E_LICENSE_LIMIT_REACHED, Times: 5})
+	var delays []time.Duration
+	err := lexfloatclient.AcquireWhenAvailable(context.Background(), lexfloatclient.AcquirePolicy{
+		Interval: time.Hour,
+		Backoff:  &lexfloatclient.Backoff{Initial: time.Millisecond, Max: 4 * time.Millisecond, Multiplier: 2, Jitter: -1},
+		OnProgress: func(p lexfloatclient.AcquireProgress) {
+			delays = append(delays, p.Next)
+		},
+	})
+	if err != nil {
+		t.Fatalf("AcquireWhenAvailable: %v", err)
+	}
+	// The delay doubles up to the cap; Interval is ignored.
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	if !equalDurations(delays, want) {
+		t.Errorf("delays = %v, want %v", delays, want)
+	}
+}
+
+func TestAcquireWhenAvailableErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: lexfloatclient.LF_E_LICENSE_NOT_FOUND, want: lexfloatclient.ErrLicenseNotFound},
+		{status: lexfloatclient.LF_E_INET, want: lexfloatclient.ErrInet},
+		{status: lexfloatclient.LF_E_LICENSE_EXISTS, want: lexfloatclient.ErrLicenseExists},
+	}
+	for _, test := range tests {
+		t.Run(lexfloatclient.StatusCode(test.status).String(), func(t *testing.T) {
+			server := lexfloattest.NewServer(lexfloattest.Config{})
+			useBackend(t, server, server.NewBackend())
+			server.AddFault(lexfloattest.Fault{Op: lexfloattest.OpRequestFloatingLicense, Status: test.status})
+			progressed := false
+			err := lexfloatclient.AcquireWhenAvailable(context.Background(), lexfloatclient.AcquirePolicy{
+				OnProgress: func(lexfloatclient.AcquireProgress) { progressed = true },
+			})
+			if !errors.Is(err, test.want) {
+				t.Errorf("AcquireWhenAvailable = %v, want %v", err, test.want)
+			}
+			if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != 1 || progressed {
+				t.Errorf("lease requests = %d, progress reported = %t, want a single request without progress", got, progressed)
+			}
+		})
+	}
+}
+
+func TestAcquireWhenAvailableContext(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{LicenseLimit: 1})
+	useBackend(t, server, server.NewBackend())
+	seatHolder(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	var next time.Duration
+	done := make(chan error, 1)
+	go func() {
+		done <- lexfloatclient.AcquireWhenAvailable(ctx, lexfloatclient.AcquirePolicy{
+			OnProgress: func(p lexfloatclient.AcquireProgress) {
+				next = p.Next
+				// Cancel while waiting for the default interval of 30s.
+				cancel()
+			},
+		})
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("AcquireWhenAvailable = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("AcquireWhenAvailable did not return after the context was cancelled")
+	}
+	if next != 30*time.Second {
+		t.Errorf("Next = %v, want the default interval of 30s", next)
+	}
+	if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != 2 {
+		t.Errorf("lease requests = %d, want 2 including the seat holder", got)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "sync"
+
+// Backend is the implementation of the LexFloatClient library that the
+// functions of this package delegate to. Its methods mirror the functions
+// declared in LexFloatClient.h and return the same status codes.
+//
+// The default backend calls into the native library through cgo. When the
+// package is built with CGO_ENABLED=0, the default backend returns LF_FAIL
+// from every method, and an alternative such as the simulator in the
+// lexfloattest package has to be installed with SetBackend.
+type Backend interface {
+	SetHostProductId(productId string) int
+	SetHostUrl(hostUrl string) int
+	SetPermissionFlag(flags uint) int
+	SetFloatingLicenseCallback(callbackFunction func(int)) int
+	SetFloatingClientMetadata(key string, value string) int
+	GetHostConfigInternal(hostConfigJson *string) int
+	GetFloatingClientLibraryVersion(libraryVersion *string) int
+	GetHostProductVersionName(name *string) int
+	GetHostProductVersionDisplayName(displayName *string) int
+	GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string) int
+	GetHostLicenseEntitlementSetName(name *string) int
+	GetHostLicenseEntitlementSetDisplayName(displayName *string) int
+	GetHostLicenseEntitlementSetTier(tier *int64) int
+	GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson *string) int
+	GetHostFeatureEntitlementInternal(name string, hostFeatureEntitlementJson *string) int
+	GetHostProductMetadata(key string, value *string) int
+	GetHostLicenseMetadata(key string, value *string) int
+	GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *uint64, grossUses *uint64) int
+	GetHostLicenseExpiryDate(expiryDate *uint) int
+	GetFloatingClientMeterAttributeUses(name string, uses *uint) int
+	GetFloatingClientMetadata(key string, value *string) int
+	RequestFloatingLicense() int
+	GetFloatingClientLeaseExpiryDate(leaseExpiryDate *uint) int
+	DropFloatingLicense() int
+	HasFloatingLicense() int
+	GetFloatingLicenseMode(mode *string) int
+	RequestOfflineFloatingLicense(leaseDuration uint) int
+	IncrementFloatingClientMeterAttributeUses(name string, increment uint) int
+	DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int
+	ResetFloatingClientMeterAttributeUses(name string) int
+}
+
+var (
+	backendMutex sync.RWMutex
+	backend      Backend
+)
+
+// SetBackend replaces the backend used by the functions of this package.
+//
+// Passing nil restores the default backend. The previous backend is returned
+// so that tests can restore it:
+//
+//	defer lexfloatclient.SetBackend(lexfloatclient.SetBackend(fake))
+func SetBackend(newBackend Backend) Backend {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	previous := backend
+	if previous == nil {
+		previous = defaultBackend()
+	}
+	backend = newBackend
+	return previous
+}
+
+func currentBackend() Backend {
+	backendMutex.RLock()
+	defer backendMutex.RUnlock()
+	if backend == nil {
+		return defaultBackend()
+	}
+	return backend
+}
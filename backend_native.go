@@ -0,0 +1,293 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+/*
+#cgo linux,!arm64 LDFLAGS: -Wl,-Bstatic -L${SRCDIR}/libs/linux_amd64 -lLexFloatClient -Wl,-Bdynamic -lm -lstdc++ -lpthread
+#cgo linux,arm64 LDFLAGS: -Wl,-Bstatic -L${SRCDIR}/libs/linux_arm64 -lLexFloatClient -Wl,-Bdynamic -lm -lstdc++ -lpthread
+#cgo darwin LDFLAGS: -L${SRCDIR}/libs/darwin_universal -lLexFloatClient -lc++ -framework CoreFoundation -framework SystemConfiguration -framework Security
+#cgo windows LDFLAGS: -L${SRCDIR}/libs/windows_amd64 -lLexFloatClient
+#include "lexfloatclient/LexFloatClient.h"
+#include <stdlib.h>
+void floatingLicenseCallbackCgoGateway(int status);
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// nativeBackend calls into the LexFloatClient library through cgo.
+type nativeBackend struct{}
+
+var (
+	nativeFloatingLicenseCallbackMutex    sync.RWMutex
+	nativeFloatingLicenseCallbackFunction callbackType
+)
+
+//export floatingLicenseCallbackCgoWrapper
+func floatingLicenseCallbackCgoWrapper(status int) {
+	nativeFloatingLicenseCallbackMutex.RLock()
+	callbackFunction := nativeFloatingLicenseCallbackFunction
+	nativeFloatingLicenseCallbackMutex.RUnlock()
+	if callbackFunction != nil {
+		callbackFunction(status)
+	}
+}
+
+// getCString calls get with a heap buffer that grows as described at growBuffer.
+func getCString(get func(buffer *cChar, length C.uint) C.int) (string, C.int) {
+	var goString string
+	status := growBuffer(func(length uint) int {
+		buffer := make([]cChar, length)
+		status := get(&buffer[0], C.uint(length))
+		goString = ctoGoString(buffer)
+		return int(status)
+	})
+	return goString, C.int(status)
+}
+
+func defaultBackend() Backend {
+	return nativeBackend{}
+}
+
+func (nativeBackend) SetFloatingLicenseCallback(callbackFunction func(int)) int {
+	nativeFloatingLicenseCallbackMutex.Lock()
+	nativeFloatingLicenseCallbackFunction = callbackFunction
+	nativeFloatingLicenseCallbackMutex.Unlock()
+	status := C.SetFloatingLicenseCallback((C.CallbackType)(unsafe.Pointer(C.floatingLicenseCallbackCgoGateway)))
+	return int(status)
+}
+
+func (nativeBackend) GetHostConfigInternal(hostConfigJson *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostConfigInternal(buffer, length)
+	})
+	*hostConfigJson = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostFeatureEntitlementsInternal(buffer, length)
+	})
+	*hostFeatureEntitlementsJson = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostFeatureEntitlementInternal(name string, hostFeatureEntitlementJson *string) int {
+	cName := goToCString(name)
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostFeatureEntitlementInternal(cName, buffer, length)
+	})
+	freeCString(cName)
+	*hostFeatureEntitlementJson = goString
+	return int(status)
+}
+
+func (nativeBackend) SetPermissionFlag(flags uint) int {
+	cFlags := (C.uint)(flags)
+	status := C.SetPermissionFlag(cFlags)
+	return int(status)
+}
+
+func (nativeBackend) SetHostProductId(productId string) int {
+	cProductId := goToCString(productId)
+	status := C.SetHostProductId(cProductId)
+	freeCString(cProductId)
+	return int(status)
+}
+
+func (nativeBackend) SetHostUrl(hostUrl string) int {
+	cHostUrl := goToCString(hostUrl)
+	status := C.SetHostUrl(cHostUrl)
+	freeCString(cHostUrl)
+	return int(status)
+}
+
+func (nativeBackend) SetFloatingClientMetadata(key string, value string) int {
+	cKey := goToCString(key)
+	cValue := goToCString(value)
+	status := C.SetFloatingClientMetadata(cKey, cValue)
+	freeCString(cKey)
+	freeCString(cValue)
+	return int(status)
+}
+
+func (nativeBackend) GetFloatingClientLibraryVersion(libraryVersion *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetFloatingClientLibraryVersion(buffer, length)
+	})
+	*libraryVersion = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostProductVersionName(name *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostProductVersionName(buffer, length)
+	})
+	*name = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostProductVersionDisplayName(displayName *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostProductVersionDisplayName(buffer, length)
+	})
+	*displayName = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string) int {
+	cName := goToCString(name)
+	var cEnabled C.uint
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostProductVersionFeatureFlag(cName, &cEnabled, buffer, length)
+	})
+	freeCString(cName)
+	*enabled = cEnabled > 0
+	*data = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseEntitlementSetName(name *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostLicenseEntitlementSetName(buffer, length)
+	})
+	*name = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseEntitlementSetDisplayName(displayName *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostLicenseEntitlementSetDisplayName(buffer, length)
+	})
+	*displayName = goString
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseEntitlementSetTier(tier *int64) int {
+	var cTier C.int64_t
+	status := C.GetHostLicenseEntitlementSetTier(&cTier)
+	*tier = int64(cTier)
+	return int(status)
+}
+
+func (nativeBackend) GetHostProductMetadata(key string, value *string) int {
+	cKey := goToCString(key)
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostProductMetadata(cKey, buffer, length)
+	})
+	*value = goString
+	freeCString(cKey)
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseMetadata(key string, value *string) int {
+	cKey := goToCString(key)
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetHostLicenseMetadata(cKey, buffer, length)
+	})
+	*value = goString
+	freeCString(cKey)
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *uint64, grossUses *uint64) int {
+	cName := goToCString(name)
+	var cAllowedUses C.int64_t
+	var cTotalUses C.uint64_t
+	var cGrossUses C.uint64_t
+	status := C.GetHostLicenseMeterAttribute(cName, &cAllowedUses, &cTotalUses, &cGrossUses)
+	*allowedUses = int64(cAllowedUses)
+	*totalUses = uint64(cTotalUses)
+	*grossUses = uint64(cGrossUses)
+	freeCString(cName)
+	return int(status)
+}
+
+func (nativeBackend) GetHostLicenseExpiryDate(expiryDate *uint) int {
+	var cExpiryDate C.uint
+	status := C.GetHostLicenseExpiryDate(&cExpiryDate)
+	*expiryDate = uint(cExpiryDate)
+	return int(status)
+}
+
+func (nativeBackend) GetFloatingClientMeterAttributeUses(name string, uses *uint) int {
+	cName := goToCString(name)
+	var cUses C.uint
+	status := C.GetFloatingClientMeterAttributeUses(cName, &cUses)
+	*uses = uint(cUses)
+	freeCString(cName)
+	return int(status)
+}
+
+func (nativeBackend) GetFloatingClientMetadata(key string, value *string) int {
+	cKey := goToCString(key)
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetFloatingClientMetadata(cKey, buffer, length)
+	})
+	*value = goString
+	freeCString(cKey)
+	return int(status)
+
+}
+
+func (nativeBackend) RequestFloatingLicense() int {
+	status := C.RequestFloatingLicense()
+	return int(status)
+}
+
+func (nativeBackend) GetFloatingClientLeaseExpiryDate(leaseExpiryDate *uint) int {
+	var cLeaseExpiryDate C.uint
+	status := C.GetFloatingClientLeaseExpiryDate(&cLeaseExpiryDate)
+	*leaseExpiryDate = uint(cLeaseExpiryDate)
+	return int(status)
+}
+
+func (nativeBackend) DropFloatingLicense() int {
+	status := C.DropFloatingLicense()
+	return int(status)
+}
+
+func (nativeBackend) HasFloatingLicense() int {
+	status := C.HasFloatingLicense()
+	return int(status)
+}
+
+func (nativeBackend) GetFloatingLicenseMode(mode *string) int {
+	goString, status := getCString(func(buffer *cChar, length C.uint) C.int {
+		return C.GetFloatingLicenseMode(buffer, length)
+	})
+	*mode = goString
+	return int(status)
+}
+
+func (nativeBackend) RequestOfflineFloatingLicense(leaseDuration uint) int {
+	cLeaseDuration := (C.uint)(leaseDuration)
+	status := C.RequestOfflineFloatingLicense(cLeaseDuration)
+	return int(status)
+}
+
+func (nativeBackend) IncrementFloatingClientMeterAttributeUses(name string, increment uint) int {
+	cName := goToCString(name)
+	cIncrement := (C.uint)(increment)
+	status := C.IncrementFloatingClientMeterAttributeUses(cName, cIncrement)
+	freeCString(cName)
+	return int(status)
+}
+
+func (nativeBackend) DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int {
+	cName := goToCString(name)
+	cDecrement := (C.uint)(decrement)
+	status := C.DecrementFloatingClientMeterAttributeUses(cName, cDecrement)
+	freeCString(cName)
+	return int(status)
+}
+
+func (nativeBackend) ResetFloatingClientMeterAttributeUses(name string) int {
+	cName := goToCString(name)
+	status := C.ResetFloatingClientMeterAttributeUses(cName)
+	freeCString(cName)
+	return int(status)
+}
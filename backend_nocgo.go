@@ -0,0 +1,92 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+//go:build !cgo
+// +build !cgo
+
+package lexfloatclient
+
+// unavailableBackend is the default backend when cgo is disabled. Every
+// method fails with LF_FAIL.
+type unavailableBackend struct{}
+
+func defaultBackend() Backend {
+	return unavailableBackend{}
+}
+
+func (unavailableBackend) SetHostProductId(productId string) int { return LF_FAIL }
+
+func (unavailableBackend) SetHostUrl(hostUrl string) int { return LF_FAIL }
+
+func (unavailableBackend) SetPermissionFlag(flags uint) int { return LF_FAIL }
+
+func (unavailableBackend) SetFloatingLicenseCallback(callbackFunction func(int)) int { return LF_FAIL }
+
+func (unavailableBackend) SetFloatingClientMetadata(key string, value string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostConfigInternal(hostConfigJson *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetFloatingClientLibraryVersion(libraryVersion *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostProductVersionName(name *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostProductVersionDisplayName(displayName *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetHostLicenseEntitlementSetName(name *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostLicenseEntitlementSetDisplayName(displayName *string) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetHostLicenseEntitlementSetTier(tier *int64) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson *string) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetHostFeatureEntitlementInternal(name string, hostFeatureEntitlementJson *string) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetHostProductMetadata(key string, value *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostLicenseMetadata(key string, value *string) int { return LF_FAIL }
+
+func (unavailableBackend) GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *uint64, grossUses *uint64) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetHostLicenseExpiryDate(expiryDate *uint) int { return LF_FAIL }
+
+func (unavailableBackend) GetFloatingClientMeterAttributeUses(name string, uses *uint) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) GetFloatingClientMetadata(key string, value *string) int { return LF_FAIL }
+
+func (unavailableBackend) RequestFloatingLicense() int { return LF_FAIL }
+
+func (unavailableBackend) GetFloatingClientLeaseExpiryDate(leaseExpiryDate *uint) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) DropFloatingLicense() int { return LF_FAIL }
+
+func (unavailableBackend) HasFloatingLicense() int { return LF_FAIL }
+
+func (unavailableBackend) GetFloatingLicenseMode(mode *string) int { return LF_FAIL }
+
+func (unavailableBackend) RequestOfflineFloatingLicense(leaseDuration uint) int { return LF_FAIL }
+
+func (unavailableBackend) IncrementFloatingClientMeterAttributeUses(name string, increment uint) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int {
+	return LF_FAIL
+}
+
+func (unavailableBackend) ResetFloatingClientMeterAttributeUses(name string) int { return LF_FAIL }
@@ -0,0 +1,85 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff configures jittered exponential backoff between retries.
+type Backoff struct {
+	// Initial is the delay before the first retry. Default: 1 second.
+	Initial time.Duration
+
+	// Max caps the delay between retries. Default: 1 minute.
+	Max time.Duration
+
+	// Multiplier is the factor the delay grows by after each retry. Default: 2.
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to the given fraction in either
+	// direction, e.g. 0.2 for ±20%. Default: 0.2. Negative disables jitter.
+	Jitter float64
+}
+
+const (
+	defaultBackoffInitial    = time.Second
+	defaultBackoffMax        = time.Minute
+	defaultBackoffMultiplier = 2
+	defaultBackoffJitter     = 0.2
+)
+
+func (backoff Backoff) withDefaults() Backoff {
+	if backoff.Initial <= 0 {
+		backoff.Initial = defaultBackoffInitial
+	}
+	if backoff.Max <= 0 {
+		backoff.Max = defaultBackoffMax
+	}
+	if backoff.Max < backoff.Initial {
+		backoff.Max = backoff.Initial
+	}
+	if backoff.Multiplier < 1 {
+		backoff.Multiplier = defaultBackoffMultiplier
+	}
+	if backoff.Jitter == 0 {
+		backoff.Jitter = defaultBackoffJitter
+	}
+	if backoff.Jitter < 0 {
+		backoff.Jitter = 0
+	}
+	if backoff.Jitter > 1 {
+		backoff.Jitter = 1
+	}
+	return backoff
+}
+
+// Delay returns the delay before the given retry, counted from 0.
+func (backoff Backoff) Delay(retry int) time.Duration {
+	backoff = backoff.withDefaults()
+	delay := float64(backoff.Initial)
+	for i := 0; i < retry && delay < float64(backoff.Max); i++ {
+		delay *= backoff.Multiplier
+	}
+	if delay > float64(backoff.Max) {
+		delay = float64(backoff.Max)
+	}
+	if backoff.Jitter > 0 {
+		delay += delay * backoff.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
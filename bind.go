@@ -0,0 +1,268 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sources of the values bound by Bind.
+const (
+	bindSourceEntitlement     = "entitlement"
+	bindSourceLicenseMetadata = "license_meta"
+	bindSourceProductMetadata = "product_meta"
+)
+
+// BindError is returned by Bind when a struct field cannot be bound.
+type BindError struct {
+	// Field is the path of the struct field, e.g. "Plan.MaxProjects".
+	Field string
+
+	// Source is the source in the struct tag: "entitlement", "license_meta"
+	// or "product_meta". It is empty for invalid struct tags.
+	Source string
+
+	// Key is the name of the feature or the metadata key.
+	Key string
+
+	// Err describes the failure.
+	Err error
+}
+
+func (err *BindError) Error() string {
+	if err.Source == "" {
+		return fmt.Sprintf("lexfloatclient: binding %s: %v", err.Field, err.Err)
+	}
+	return fmt.Sprintf("lexfloatclient: binding %s to %s %q: %v", err.Field, err.Source, err.Key, err.Err)
+}
+
+func (err *BindError) Unwrap() error {
+	return err.Err
+}
+
+// bindTag is a parsed lexfloat struct tag.
+type bindTag struct {
+	source       string
+	key          string
+	defaultValue string
+	hasDefault   bool
+	required     bool
+}
+
+func parseBindTag(tag string) (bindTag, error) {
+	var parsed bindTag
+	for _, option := range strings.Split(tag, ",") {
+		option = strings.TrimSpace(option)
+		name, value := option, ""
+		if index := strings.Index(option, "="); index >= 0 {
+			name, value = option[:index], option[index+1:]
+		}
+		switch name {
+		case bindSourceEntitlement, bindSourceLicenseMetadata, bindSourceProductMetadata:
+			if parsed.source != "" {
+				return bindTag{}, fmt.Errorf("struct tag %q has more than one source", tag)
+			}
+			if value == "" {
+				return bindTag{}, fmt.Errorf("struct tag %q has an empty %s name", tag, name)
+			}
+			parsed.source, parsed.key = name, value
+		case "default":
+			parsed.defaultValue, parsed.hasDefault = value, true
+		case "required":
+			parsed.required = true
+		default:
+			return bindTag{}, fmt.Errorf("struct tag %q has unknown option %q", tag, name)
+		}
+	}
+	if parsed.source == "" {
+		return bindTag{}, fmt.Errorf("struct tag %q has no entitlement, license_meta or product_meta", tag)
+	}
+	return parsed, nil
+}
+
+// binder caches the feature entitlements for the duration of a Bind call.
+type binder struct {
+	entitlements map[string]HostFeatureEntitlement
+	now          time.Time
+}
+
+// Bind fills the fields of the struct pointed to by target from the feature
+// entitlements, the license metadata and the product metadata of the
+// LexFloatServer license. Fields are selected with the lexfloat struct tag:
+//
+//	type Plan struct {
+//		MaxProjects int           `lexfloat:"entitlement=max_projects,default=3"`
+//		Export      bool          `lexfloat:"entitlement=export"`
+//		Retention   time.Duration `lexfloat:"entitlement=retention,default=30d"`
+//		Region      string        `lexfloat:"license_meta=region,required"`
+//		Edition     string        `lexfloat:"product_meta=edition"`
+//	}
+//
+// The tag names exactly one source with the feature name or metadata key, and
+// optionally a default value, which cannot contain commas, and the required
+// option. Expired feature entitlements are treated as absent. An absent value
+// is replaced by the default value, reported as an error if the field is
+// required, and otherwise leaves the field unchanged.
+//
+// Fields of type string, bool, int, uint and float of any size and
+// time.Duration are supported, parsed like the HostFeatureEntitlement
+// accessors. Nested structs without a tag are bound recursively.
+//
+// Parameters:
+// - target: pointer to a struct
+//
+// Errors: *BindError or see HostFeatureEntitlements
+func Bind(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("lexfloatclient: Bind target must be a non-nil pointer to a struct, got %T", target)
+	}
+	return (&binder{now: currentTime()}).bindStruct(value.Elem(), value.Elem().Type().Name())
+}
+
+func (binder *binder) bindStruct(value reflect.Value, path string) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		tag, ok := field.Tag.Lookup("lexfloat")
+		if !ok {
+			if field.Type.Kind() == reflect.Struct && field.PkgPath == "" {
+				if err := binder.bindStruct(value.Field(i), fieldPath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			return &BindError{Field: fieldPath, Err: errors.New("field is not exported")}
+		}
+		parsed, err := parseBindTag(tag)
+		if err != nil {
+			return &BindError{Field: fieldPath, Err: err}
+		}
+		if err := binder.bindField(value.Field(i), parsed); err != nil {
+			return &BindError{Field: fieldPath, Source: parsed.source, Key: parsed.key, Err: err}
+		}
+	}
+	return nil
+}
+
+func (binder *binder) bindField(field reflect.Value, tag bindTag) error {
+	raw, found, err := binder.lookup(tag)
+	if err != nil {
+		return err
+	}
+	if !found {
+		switch {
+		case tag.required && tag.source == bindSourceEntitlement:
+			return fmt.Errorf("required value is absent: %w", ErrFeatureEntitlementNotFound)
+		case tag.required:
+			return fmt.Errorf("required value is absent: %w", ErrMetadataKeyNotFound)
+		case tag.hasDefault:
+			raw = tag.defaultValue
+		default:
+			return nil
+		}
+	}
+	return setBindValue(field, raw)
+}
+
+// lookup returns the raw value named by tag.
+func (binder *binder) lookup(tag bindTag) (string, bool, error) {
+	var (
+		value string
+		err   error
+	)
+	switch tag.source {
+	case bindSourceEntitlement:
+		if binder.entitlements == nil {
+			hostFeatureEntitlements, err := HostFeatureEntitlements()
+			if err != nil {
+				return "", false, err
+			}
+			binder.entitlements = make(map[string]HostFeatureEntitlement, len(hostFeatureEntitlements))
+			for _, hostFeatureEntitlement := range hostFeatureEntitlements {
+				binder.entitlements[hostFeatureEntitlement.FeatureName] = hostFeatureEntitlement
+			}
+		}
+		hostFeatureEntitlement, ok := binder.entitlements[tag.key]
+		if !ok || hostFeatureEntitlement.Expired(binder.now) {
+			return "", false, nil
+		}
+		return hostFeatureEntitlement.Value, true, nil
+	case bindSourceLicenseMetadata:
+		value, err = HostLicenseMetadata(tag.key)
+	case bindSourceProductMetadata:
+		value, err = HostProductMetadata(tag.key)
+	}
+	if errors.Is(err, ErrMetadataKeyNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setBindValue parses raw into field.
+func setBindValue(field reflect.Value, raw string) error {
+	parser := HostFeatureEntitlement{Value: raw}
+	var err error
+	switch {
+	case field.Type() == durationType:
+		var duration time.Duration
+		if duration, err = parser.Duration(); err == nil {
+			field.SetInt(int64(duration))
+		}
+	case field.Kind() == reflect.String:
+		field.SetString(raw)
+	case field.Kind() == reflect.Bool:
+		var enabled bool
+		if enabled, err = parser.Bool(); err == nil {
+			field.SetBool(enabled)
+		}
+	case field.Kind() >= reflect.Int && field.Kind() <= reflect.Int64:
+		var number int64
+		if number, err = parser.Int64(); err == nil {
+			if field.OverflowInt(number) {
+				return fmt.Errorf("value %q overflows %s: %w", raw, field.Type(), strconv.ErrRange)
+			}
+			field.SetInt(number)
+		}
+	case field.Kind() >= reflect.Uint && field.Kind() <= reflect.Uint64:
+		number, parseErr := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("cannot parse value %q as uint64: %w", raw, unwrapNumError(parseErr))
+		}
+		if field.OverflowUint(number) {
+			return fmt.Errorf("value %q overflows %s: %w", raw, field.Type(), strconv.ErrRange)
+		}
+		field.SetUint(number)
+	case field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64:
+		var number float64
+		if number, err = parser.Float64(); err == nil {
+			if field.OverflowFloat(number) {
+				return fmt.Errorf("value %q overflows %s: %w", raw, field.Type(), strconv.ErrRange)
+			}
+			field.SetFloat(number)
+		}
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	var valueError *EntitlementValueError
+	if errors.As(err, &valueError) {
+		return fmt.Errorf("cannot parse value %q as %s: %w", raw, valueError.Type, valueError.Err)
+	}
+	return err
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// leasedServer returns an installed server whose client holds a lease.
+func leasedServer(t *testing.T, config lexfloattest.Config) *lexfloattest.Server {
+	t.Helper()
+	server := lexfloattest.NewServer(config)
+	useBackend(t, server, server.NewBackend())
+	if err := lexfloatclient.RequestFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("RequestFloatingLicenseContext: %v", err)
+	}
+	return server
+}
+
+// bindEntitlement binds value, the value of a feature entitlement, to target.
+func bindEntitlement(t *testing.T, value string, target interface{}) error {
+	t.Helper()
+	leasedServer(t, lexfloattest.Config{
+		Entitlements: []lexfloatclient.HostFeatureEntitlement{
+			{FeatureName: "feature", Value: value},
+		},
+	})
+	return lexfloatclient.Bind(target)
+}
+
+func TestBind(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	leasedServer(t, lexfloattest.Config{
+		Start: start,
+		Entitlements: []lexfloatclient.HostFeatureEntitlement{
+			{FeatureName: "max_projects", Value: "12"},
+			{FeatureName: "export", Value: "yes"},
+			{FeatureName: "retention", Value: "2w"},
+			{FeatureName: "ratio", Value: "0.5"},
+			{FeatureName: "expired", Value: "99", ExpiresAt: start.Add(-time.Hour).Unix()},
+		},
+		LicenseMetadata: map[string]string{"region": "eu"},
+		ProductMetadata: map[string]string{"edition": "enterprise"},
+	})
+	type limits struct {
+		Seats   uint16 `lexfloat:"entitlement=max_projects"`
+		Expired int    `lexfloat:"entitlement=expired,default=1"`
+	}
+	var plan struct {
+		MaxProjects int           `lexfloat:"entitlement=max_projects,default=3"`
+		Export      bool          `lexfloat:"entitlement=export"`
+		Retention   time.Duration `lexfloat:"entitlement=retention,default=30d"`
+		Ratio       float32       `lexfloat:"entitlement=ratio"`
+		Region      string        `lexfloat:"license_meta=region,required"`
+		Edition     string        `lexfloat:"product_meta=edition"`
+		Tier        string        `lexfloat:"license_meta=tier,default=basic"`
+		Audit       bool          `lexfloat:"entitlement=audit"`
+		Limits      limits
+		Untagged    string
+	}
+	plan.Audit = true
+	plan.Untagged = "kept"
+	if err := lexfloatclient.Bind(&plan); err != nil {
+		t.Fatalf("Bind: %v", err)
+	}
+	checks := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"MaxProjects", plan.MaxProjects, 12},
+		{"Export", plan.Export, true},
+		{"Retention", plan.Retention, 14 * 24 * time.Hour},
+		{"Ratio", plan.Ratio, float32(0.5)},
+		{"Region", plan.Region, "eu"},
+		{"Edition", plan.Edition, "enterprise"},
+		{"Tier", plan.Tier, "basic"},
+		{"Audit", plan.Audit, true},
+		{"Limits.Seats", plan.Limits.Seats, uint16(12)},
+		{"Limits.Expired", plan.Limits.Expired, 1},
+		{"Untagged", plan.Untagged, "kept"},
+	}
+	for _, check := range checks {
+		if check.got != check.want {
+			t.Errorf("%s = %v, want %v", check.name, check.got, check.want)
+		}
+	}
+}
+
+func TestBindRequired(t *testing.T) {
+	leasedServer(t, lexfloattest.Config{})
+	var entitlement struct {
+		MaxProjects int `lexfloat:"entitlement=max_projects,required"`
+	}
+	if err := lexfloatclient.Bind(&entitlement); !errors.Is(err, lexfloatclient.ErrFeatureEntitlementNotFound) {
+		t.Errorf("entitlement: Bind = %v, want ErrFeatureEntitlementNotFound", err)
+	}
+	var metadata struct {
+		Region string `lexfloat:"license_meta=region,required,default=eu"`
+	}
+	err := lexfloatclient.Bind(&metadata)
+	var bindError *lexfloatclient.BindError
+	if !errors.As(err, &bindError) || !errors.Is(err, lexfloatclient.ErrMetadataKeyNotFound) {
+		t.Fatalf("metadata: Bind = %v, want *BindError wrapping ErrMetadataKeyNotFound", err)
+	}
+	if bindError.Field != "Region" || bindError.Source != "license_meta" || bindError.Key != "region" {
+		t.Errorf("BindError = %+v, want field Region, source license_meta and key region", bindError)
+	}
+}
+
+func TestBindDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr error
+	}{
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "1e10d", wantErr: strconv.ErrRange},
+		{value: "NaNd", wantErr: strconv.ErrSyntax},
+		{value: "infw", wantErr: strconv.ErrRange},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			var plan struct {
+				Retention time.Duration `lexfloat:"entitlement=feature"`
+			}
+			err := bindEntitlement(t, test.value, &plan)
+			if test.wantErr == nil {
+				if err != nil || plan.Retention != test.want {
+					t.Errorf("Bind = %v, Retention = %v, want %v", err, plan.Retention, test.want)
+				}
+				return
+			}
+			var bindError *lexfloatclient.BindError
+			if !errors.As(err, &bindError) || !errors.Is(err, test.wantErr) {
+				t.Fatalf("Bind = %v, want *BindError wrapping %v", err, test.wantErr)
+			}
+			if bindError.Field != "Retention" || bindError.Key != "feature" {
+				t.Errorf("BindError = %+v, want field Retention and key feature", bindError)
+			}
+			if plan.Retention != 0 {
+				t.Errorf("Retention = %v, want unchanged", plan.Retention)
+			}
+		})
+	}
+}
+
+func TestBindNumbers(t *testing.T) {
+	t.Run("uint64 above MaxInt64", func(t *testing.T) {
+		var plan struct {
+			Value uint64 `lexfloat:"entitlement=feature"`
+		}
+		if err := bindEntitlement(t, "18446744073709551615", &plan); err != nil || plan.Value != 18446744073709551615 {
+			t.Errorf("Bind = %v, Value = %d, want 18446744073709551615", err, plan.Value)
+		}
+	})
+	tests := []struct {
+		name    string
+		value   string
+		target  interface{}
+		wantErr error
+	}{
+		{"int8 overflow", "300", &struct {
+			Value int8 `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrRange},
+		{"int64 overflow", "9223372036854775808", &struct {
+			Value int64 `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrRange},
+		{"uint8 overflow", "256", &struct {
+			Value uint8 `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrRange},
+		{"uint64 overflow", "18446744073709551616", &struct {
+			Value uint64 `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrRange},
+		{"negative uint", "-1", &struct {
+			Value uint `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrSyntax},
+		{"float32 overflow", "1e39", &struct {
+			Value float32 `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrRange},
+		{"invalid bool", "maybe", &struct {
+			Value bool `lexfloat:"entitlement=feature"`
+		}{}, strconv.ErrSyntax},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := bindEntitlement(t, test.value, test.target)
+			var bindError *lexfloatclient.BindError
+			if !errors.As(err, &bindError) || !errors.Is(err, test.wantErr) {
+				t.Errorf("Bind = %v, want *BindError wrapping %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestBindInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{"not a pointer", struct{}{}},
+		{"nil pointer", (*struct{})(nil)},
+		{"unsupported type", &struct {
+			Value []string `lexfloat:"entitlement=feature"`
+		}{}},
+		{"unexported field", &struct {
+			value string `lexfloat:"entitlement=feature"`
+		}{}},
+		{"no source", &struct {
+			Value string `lexfloat:"default=1"`
+		}{}},
+		{"two sources", &struct {
+			Value string `lexfloat:"entitlement=feature,license_meta=feature"`
+		}{}},
+		{"unknown option", &struct {
+			Value string `lexfloat:"entitlement=feature,optional"`
+		}{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := bindEntitlement(t, "1", test.target); err == nil {
+				t.Errorf("Bind(%T) = nil, want an error", test.target)
+			}
+		})
+	}
+}
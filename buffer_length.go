@@ -0,0 +1,54 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "sync/atomic"
+
+const (
+	initialBufferLength    uint = 4096
+	defaultMaxBufferLength uint = 1 << 20
+)
+
+var maxBufferLength = uint64(defaultMaxBufferLength)
+
+// SetMaxBufferLength sets the maximum length, in characters, of the buffers
+// used by the functions that get strings from the library. The buffers start
+// at 4096 characters and are doubled up to this length for as long as the
+// library returns LF_E_BUFFER_SIZE. The default is 1048576 characters; lengths
+// below 4096 are raised to 4096.
+//
+// Parameters:
+// - length: maximum buffer length in characters
+//
+// Returns: the previous maximum buffer length
+func SetMaxBufferLength(length uint) uint {
+	if length < initialBufferLength {
+		length = initialBufferLength
+	}
+	return uint(atomic.SwapUint64(&maxBufferLength, uint64(length)))
+}
+
+func maxBufferLengthValue() uint {
+	return uint(atomic.LoadUint64(&maxBufferLength))
+}
+
+// growBuffer calls get with a buffer length that starts at 4096 characters and
+// is doubled, up to the length set with SetMaxBufferLength, for as long as get
+// returns LF_E_BUFFER_SIZE.
+//
+// Returns: the status of the last call, LF_E_BUFFER_SIZE if the string does not
+// fit into a buffer of the maximum length
+func growBuffer(get func(length uint) int) int {
+	length := initialBufferLength
+	maxLength := maxBufferLengthValue()
+	for {
+		status := get(length)
+		if status != LF_E_BUFFER_SIZE || length >= maxLength {
+			return status
+		}
+		length *= 2
+		if length > maxLength {
+			length = maxLength
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGrowBuffer(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxLength   uint
+		needed      uint
+		status      int
+		wantLengths []uint
+		wantStatus  int
+	}{
+		{name: "fits", maxLength: defaultMaxBufferLength, needed: 100, wantLengths: []uint{4096}, wantStatus: LF_OK},
+		{name: "fits exactly", maxLength: defaultMaxBufferLength, needed: 4096, wantLengths: []uint{4096}, wantStatus: LF_OK},
+		{name: "grows", maxLength: defaultMaxBufferLength, needed: 10000, wantLengths: []uint{4096, 8192, 16384}, wantStatus: LF_OK},
+		{
+			name:        "grows to the default maximum",
+			maxLength:   defaultMaxBufferLength,
+			needed:      defaultMaxBufferLength,
+			wantLengths: []uint{4096, 8192, 16384, 32768, 65536, 131072, 262144, 524288, 1048576},
+			wantStatus:  LF_OK,
+		},
+		{
+			name:        "exceeds the default maximum",
+			maxLength:   defaultMaxBufferLength,
+			needed:      defaultMaxBufferLength + 1,
+			wantLengths: []uint{4096, 8192, 16384, 32768, 65536, 131072, 262144, 524288, 1048576},
+			wantStatus:  LF_E_BUFFER_SIZE,
+		},
+		{name: "capped at the maximum", maxLength: 10000, needed: 10000, wantLengths: []uint{4096, 8192, 10000}, wantStatus: LF_OK},
+		{name: "exceeds the maximum", maxLength: 10000, needed: 10001, wantLengths: []uint{4096, 8192, 10000}, wantStatus: LF_E_BUFFER_SIZE},
+		{name: "maximum raised to the initial length", maxLength: 100, needed: 5000, wantLengths: []uint{4096}, wantStatus: LF_E_BUFFER_SIZE},
+		{name: "other error", maxLength: defaultMaxBufferLength, needed: 10000, status: LF_E_NO_LICENSE, wantLengths: []uint{4096}, wantStatus: LF_E_NO_LICENSE},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer SetMaxBufferLength(SetMaxBufferLength(test.maxLength))
+			var lengths []uint
+			status := growBuffer(func(length uint) int {
+				lengths = append(lengths, length)
+				if test.status != LF_OK {
+					return test.status
+				}
+				if length < test.needed {
+					return LF_E_BUFFER_SIZE
+				}
+				return LF_OK
+			})
+			if status != test.wantStatus || !reflect.DeepEqual(lengths, test.wantLengths) {
+				t.Errorf("growBuffer = %d with lengths %v, want %d with lengths %v", status, lengths, test.wantStatus, test.wantLengths)
+			}
+		})
+	}
+}
+
+func TestSetMaxBufferLength(t *testing.T) {
+	defer SetMaxBufferLength(SetMaxBufferLength(defaultMaxBufferLength))
+	if previous := SetMaxBufferLength(1 << 16); previous != defaultMaxBufferLength {
+		t.Errorf("SetMaxBufferLength = %d, want the default %d", previous, defaultMaxBufferLength)
+	}
+	if previous := SetMaxBufferLength(0); previous != 1<<16 {
+		t.Errorf("SetMaxBufferLength = %d, want %d", previous, 1<<16)
+	}
+	if got := maxBufferLengthValue(); got != initialBufferLength {
+		t.Errorf("maximum buffer length = %d, want %d", got, initialBufferLength)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	maxMetadataKeyLength   = 256
+	maxMetadataValueLength = 4096
+)
+
+// Options configures a Client.
+type Options struct {
+	// ProductID is the unique product id of your application as mentioned
+	// on the product page in the dashboard. Required.
+	ProductID string
+
+	// HostURL is the network address of the LexFloatServer in the format
+	// http://[ip or hostname]:[port]. Required.
+	HostURL string
+
+	// PermissionFlag is LF_USER or LF_ALL_USERS. Zero leaves the permission
+	// flag unset.
+	PermissionFlag uint
+
+	// Metadata is the floating client metadata that appears along with the
+	// license details in the LexFloatServer dashboard.
+	Metadata map[string]string
+
+	// OnRenew is invoked with the status of every lease renew request. It is
+	// registered with Subscribe once NewClient succeeds, unregistered by Close,
+	// and may be nil.
+	OnRenew func(status int)
+}
+
+// Client is a configured floating client.
+//
+// The LexFloatClient library keeps its configuration in process-wide state,
+// so a process should create a single Client.
+type Client struct {
+	options     Options
+	unsubscribe func()
+}
+
+// NewClient validates the options and applies them to the LexFloatClient
+// library in the required order: product id, permission flag, host url,
+// renew callback and floating client metadata. OnRenew is subscribed only if
+// all of them succeed; call Close to unsubscribe it.
+//
+// Errors wrap the StatusCode describing the invalid option or the failing
+// library call, e.g. ErrProductId, ErrHostUrl or ErrMetadataKeyLength.
+func NewClient(options Options) (*Client, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	client := &Client{options: options}
+	if err := client.apply(); err != nil {
+		return nil, err
+	}
+	if options.OnRenew != nil {
+		client.unsubscribe = Subscribe(options.OnRenew)
+	}
+	return client, nil
+}
+
+func (options Options) validate() error {
+	if options.ProductID == "" {
+		return fmt.Errorf("lexfloatclient: product id is required: %w", ErrProductId)
+	}
+	hostURL, err := url.Parse(options.HostURL)
+	if err != nil || (hostURL.Scheme != "http" && hostURL.Scheme != "https") || hostURL.Host == "" {
+		return fmt.Errorf("lexfloatclient: invalid host url %q: %w", options.HostURL, ErrHostUrl)
+	}
+	if options.PermissionFlag != 0 && options.PermissionFlag != LF_USER && options.PermissionFlag != LF_ALL_USERS {
+		return fmt.Errorf("lexfloatclient: invalid permission flag %d: %w", options.PermissionFlag, ErrInvalidPermissionFlag)
+	}
+	for key, value := range options.Metadata {
+		if utf8.RuneCountInString(key) > maxMetadataKeyLength {
+			return fmt.Errorf("lexfloatclient: metadata key %q: %w", key, ErrMetadataKeyLength)
+		}
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return fmt.Errorf("lexfloatclient: metadata value of key %q: %w", key, ErrMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+func (client *Client) apply() error {
+	options := client.options
+	if err := StatusError(SetHostProductId(options.ProductID)); err != nil {
+		return opError("SetHostProductId", err)
+	}
+	if options.PermissionFlag != 0 {
+		if err := StatusError(SetPermissionFlag(options.PermissionFlag)); err != nil {
+			return opError("SetPermissionFlag", err)
+		}
+	}
+	if err := StatusError(SetHostUrl(options.HostURL)); err != nil {
+		return opError("SetHostUrl", err)
+	}
+	if err := StatusError(installFloatingLicenseCallback()); err != nil {
+		return opError("SetFloatingLicenseCallback", err)
+	}
+	keys := make([]string, 0, len(options.Metadata))
+	for key := range options.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := StatusError(SetFloatingClientMetadata(key, options.Metadata[key])); err != nil {
+			return opError("SetFloatingClientMetadata", err)
+		}
+	}
+	return nil
+}
+
+// Close unregisters the OnRenew listener. It does not drop the lease; call
+// Drop or Shutdown first if the lease is no longer needed. Calling Close more
+// than once has no effect.
+func (client *Client) Close() {
+	if client.unsubscribe != nil {
+		client.unsubscribe()
+	}
+}
+
+// Lease sends the request to lease the license from the LexFloatServer.
+//
+// Errors: see RequestFloatingLicense
+func (client *Client) Lease() error {
+	return opError("RequestFloatingLicense", StatusError(RequestFloatingLicense()))
+}
+
+// Drop sends the request to the LexFloatServer to free the license.
+//
+// Errors: see DropFloatingLicense
+func (client *Client) Drop() error {
+	return opError("DropFloatingLicense", StatusError(DropFloatingLicense()))
+}
+
+// LeaseContext is like Lease but honours the deadline and cancellation of ctx.
+//
+// Errors: see RequestFloatingLicenseContext
+func (client *Client) LeaseContext(ctx context.Context) error {
+	return opError("RequestFloatingLicense", RequestFloatingLicenseContext(ctx))
+}
+
+// DropContext is like Drop but honours the deadline and cancellation of ctx.
+//
+// Errors: see DropFloatingLicenseContext
+func (client *Client) DropContext(ctx context.Context) error {
+	return opError("DropFloatingLicense", DropFloatingLicenseContext(ctx))
+}
+
+// Entitlements returns the feature entitlements associated with the LexFloatServer license.
+//
+// Errors: see GetHostFeatureEntitlements
+func (client *Client) Entitlements() ([]HostFeatureEntitlement, error) {
+	hostFeatureEntitlements, err := HostFeatureEntitlements()
+	return hostFeatureEntitlements, opError("GetHostFeatureEntitlements", err)
+}
+
+// Entitlement returns the feature entitlement with the given name.
+//
+// Errors: see GetHostFeatureEntitlement
+func (client *Client) Entitlement(name string) (HostFeatureEntitlement, error) {
+	hostFeatureEntitlement, err := HostFeatureEntitlementByName(name)
+	return hostFeatureEntitlement, opError("GetHostFeatureEntitlement", err)
+}
+
+// opError prefixes err with the name of the library function that failed.
+func opError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("lexfloatclient: %s: %w", op, err)
+}
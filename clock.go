@@ -0,0 +1,48 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	clockMutex sync.RWMutex
+	clock      func() time.Time
+)
+
+// SetClock replaces the function that returns the current time for the
+// expiry and lease computations of this package, e.g. with the virtual clock
+// of a simulated LexFloatServer. Timers still run in real time.
+//
+// Passing nil restores time.Now. The previous clock is returned so that tests
+// can restore it:
+//
+//	defer lexfloatclient.SetClock(lexfloatclient.SetClock(fake.Now))
+func SetClock(now func() time.Time) func() time.Time {
+	clockMutex.Lock()
+	defer clockMutex.Unlock()
+	previous := clock
+	if previous == nil {
+		previous = time.Now
+	}
+	clock = now
+	return previous
+}
+
+// currentTime returns the current time of the clock.
+func currentTime() time.Time {
+	clockMutex.RLock()
+	now := clock
+	clockMutex.RUnlock()
+	if now == nil {
+		return time.Now()
+	}
+	return now()
+}
+
+// timeUntil returns the duration until t on the clock.
+func timeUntil(t time.Time) time.Duration {
+	return t.Sub(currentTime())
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "context"
+
+// The functions in this file are variants of the network-bound functions of
+// this package that honour the deadline and cancellation of a context. The
+// library call runs on a separate goroutine; when the context is done before
+// the call returns, ctx.Err() is returned immediately and the call is left to
+// complete in the background.
+
+// runContext runs call on a separate goroutine and waits for its status or for
+// ctx to be done. If ctx is done first, late is invoked with the status once
+// the call returns.
+func runContext(ctx context.Context, call func() int, late func(status int)) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	result := make(chan int, 1)
+	go func() {
+		result <- call()
+	}()
+	select {
+	case status := <-result:
+		return status, nil
+	case <-ctx.Done():
+		if late != nil {
+			go func() {
+				late(<-result)
+			}()
+		}
+		return 0, ctx.Err()
+	}
+}
+
+// dropLateLease drops a lease that was granted after the caller gave up.
+func dropLateLease(status int) {
+	if status == LF_OK {
+		DropFloatingLicense()
+	}
+}
+
+// RequestFloatingLicenseContext sends the request to lease the license from the LexFloatServer.
+//
+// If ctx is done before the request completes, ctx.Err() is returned and a
+// lease that is granted afterwards is dropped.
+//
+// Errors: ctx.Err() or see RequestFloatingLicense
+func RequestFloatingLicenseContext(ctx context.Context) error {
+	status, err := runContext(ctx, RequestFloatingLicense, dropLateLease)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
+
+// RequestOfflineFloatingLicenseContext sends the request to lease the license from the LexFloatServer for offline usage.
+//
+// If ctx is done before the request completes, ctx.Err() is returned and a
+// lease that is granted afterwards is dropped.
+//
+// Parameters:
+// - leaseDuration: value of the lease duration in seconds.
+//
+// Errors: ctx.Err() or see RequestOfflineFloatingLicense
+func RequestOfflineFloatingLicenseContext(ctx context.Context, leaseDuration uint) error {
+	status, err := runContext(ctx, func() int {
+		return RequestOfflineFloatingLicense(leaseDuration)
+	}, dropLateLease)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
+
+// DropFloatingLicenseContext sends the request to the LexFloatServer to free the license.
+//
+// If ctx is done before the request completes, ctx.Err() is returned and the
+// request completes in the background.
+//
+// Errors: ctx.Err() or see DropFloatingLicense
+func DropFloatingLicenseContext(ctx context.Context) error {
+	status, err := runContext(ctx, DropFloatingLicense, nil)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
+
+// GetHostConfigContext gets the host configuration.
+//
+// Errors: ctx.Err() or see FetchHostConfig
+func GetHostConfigContext(ctx context.Context) (HostConfig, error) {
+	type result struct {
+		hostConfig HostConfig
+		err        error
+	}
+	results := make(chan result, 1)
+	_, err := runContext(ctx, func() int {
+		hostConfig, err := FetchHostConfig()
+		results <- result{hostConfig, err}
+		return LF_OK
+	}, nil)
+	if err != nil {
+		return HostConfig{}, err
+	}
+	fetched := <-results
+	return fetched.hostConfig, fetched.err
+}
+
+// IncrementFloatingClientMeterAttributeUsesContext increments the meter attribute uses of the floating client.
+//
+// If ctx is done before the request completes, ctx.Err() is returned. The
+// increment still takes effect if the request succeeds afterwards.
+//
+// Parameters:
+// - name: name of the meter attribute
+// - increment: the increment value
+//
+// Errors: ctx.Err() or see IncrementFloatingClientMeterAttributeUses
+func IncrementFloatingClientMeterAttributeUsesContext(ctx context.Context, name string, increment uint) error {
+	status, err := runContext(ctx, func() int {
+		return IncrementFloatingClientMeterAttributeUses(name, increment)
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
+
+// DecrementFloatingClientMeterAttributeUsesContext decrements the meter attribute uses of the floating client.
+//
+// If ctx is done before the request completes, ctx.Err() is returned. The
+// decrement still takes effect if the request succeeds afterwards.
+//
+// Parameters:
+// - name: name of the meter attribute
+// - decrement: the decrement value
+//
+// Errors: ctx.Err() or see DecrementFloatingClientMeterAttributeUses
+func DecrementFloatingClientMeterAttributeUsesContext(ctx context.Context, name string, decrement uint) error {
+	status, err := runContext(ctx, func() int {
+		return DecrementFloatingClientMeterAttributeUses(name, decrement)
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
+
+// ResetFloatingClientMeterAttributeUsesContext resets the meter attribute uses consumed by the floating client.
+//
+// If ctx is done before the request completes, ctx.Err() is returned. The
+// reset still takes effect if the request succeeds afterwards.
+//
+// Parameters:
+// - name: name of the meter attribute
+//
+// Errors: ctx.Err() or see ResetFloatingClientMeterAttributeUses
+func ResetFloatingClientMeterAttributeUsesContext(ctx context.Context, name string) error {
+	status, err := runContext(ctx, func() int {
+		return ResetFloatingClientMeterAttributeUses(name)
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return StatusError(status)
+}
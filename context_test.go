@@ -0,0 +1,175 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// gatedBackend holds lease requests until released is closed and closes
+// returned once the held request has returned.
+type gatedBackend struct {
+	*lexfloattest.Backend
+	released chan struct{}
+	returned chan struct{}
+}
+
+func newGatedBackend(server *lexfloattest.Server) *gatedBackend {
+	return &gatedBackend{
+		Backend:  server.NewBackend(),
+		released: make(chan struct{}),
+		returned: make(chan struct{}),
+	}
+}
+
+func (backend *gatedBackend) RequestFloatingLicense() int {
+	<-backend.released
+	defer close(backend.returned)
+	return backend.Backend.RequestFloatingLicense()
+}
+
+func (backend *gatedBackend) RequestOfflineFloatingLicense(leaseDuration uint) int {
+	<-backend.released
+	defer close(backend.returned)
+	return backend.Backend.RequestOfflineFloatingLicense(leaseDuration)
+}
+
+func TestContextLateLease(t *testing.T) {
+	requests := map[string]func(ctx context.Context) error{
+		"RequestFloatingLicenseContext": lexfloatclient.RequestFloatingLicenseContext,
+		"RequestOfflineFloatingLicenseContext": func(ctx context.Context) error {
+			return lexfloatclient.RequestOfflineFloatingLicenseContext(ctx, 3600)
+		},
+	}
+	for name, request := range requests {
+		t.Run(name, func(t *testing.T) {
+			server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: time.Hour})
+			backend := newGatedBackend(server)
+			useBackend(t, server, backend)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := request(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("%s = %v, want context.DeadlineExceeded", name, err)
+			}
+			// The lease is granted after the caller gave up and is dropped.
+			close(backend.released)
+			<-backend.returned
+			waitFor(t, "the late lease to be dropped", func() bool {
+				return server.ActiveLeases() == 0 && server.Calls(lexfloattest.OpDropFloatingLicense) == 1
+			})
+		})
+	}
+}
+
+func TestContextLateFailure(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	backend := newGatedBackend(server)
+	useBackend(t, server, backend)
+	server.AddFault(lexfloattest.Fault{Op: lexfloattest.OpRequestFloatingLicense, Status: lexfloatclient.LF_E_INET})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := lexfloatclient.RequestFloatingLicenseContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("RequestFloatingLicenseContext = %v, want context.DeadlineExceeded", err)
+	}
+	close(backend.released)
+	<-backend.returned
+	// A late request that failed leaves nothing to drop.
+	time.Sleep(10 * time.Millisecond)
+	if got := server.Calls(lexfloattest.OpDropFloatingLicense); got != 0 {
+		t.Errorf("drop requests = %d, want 0", got)
+	}
+	if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != 1 {
+		t.Errorf("lease requests = %d, want 1", got)
+	}
+}
+
+func TestContextCancelled(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: time.Hour, Meters: map[string]int64{"exports": 10}})
+	useBackend(t, server, server.NewBackend())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := map[string]func() error{
+		"RequestFloatingLicenseContext": func() error { return lexfloatclient.RequestFloatingLicenseContext(ctx) },
+		"RequestOfflineFloatingLicenseContext": func() error {
+			return lexfloatclient.RequestOfflineFloatingLicenseContext(ctx, 60)
+		},
+		"DropFloatingLicenseContext": func() error { return lexfloatclient.DropFloatingLicenseContext(ctx) },
+		"GetHostConfigContext": func() error {
+			_, err := lexfloatclient.GetHostConfigContext(ctx)
+			return err
+		},
+		"IncrementFloatingClientMeterAttributeUsesContext": func() error {
+			return lexfloatclient.IncrementFloatingClientMeterAttributeUsesContext(ctx, "exports", 1)
+		},
+		"DecrementFloatingClientMeterAttributeUsesContext": func() error {
+			return lexfloatclient.DecrementFloatingClientMeterAttributeUsesContext(ctx, "exports", 1)
+		},
+		"ResetFloatingClientMeterAttributeUsesContext": func() error {
+			return lexfloatclient.ResetFloatingClientMeterAttributeUsesContext(ctx, "exports")
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s = %v, want context.Canceled", name, err)
+		}
+	}
+	for _, op := range []lexfloattest.Op{
+		lexfloattest.OpRequestFloatingLicense,
+		lexfloattest.OpRequestOfflineFloatingLicense,
+		lexfloattest.OpDropFloatingLicense,
+		lexfloattest.OpGetHostConfig,
+		lexfloattest.OpIncrementFloatingClientMeterAttributeUses,
+		lexfloattest.OpDecrementFloatingClientMeterAttributeUses,
+		lexfloattest.OpResetFloatingClientMeterAttributeUses,
+	} {
+		if got := server.Calls(op); got != 0 {
+			t.Errorf("Calls(%s) = %d, want 0 with a cancelled context", op, got)
+		}
+	}
+}
+
+func TestContextCompleted(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: 2 * time.Hour, Meters: map[string]int64{"exports": 10}})
+	useBackend(t, server, server.NewBackend())
+	ctx := context.Background()
+	hostConfig, err := lexfloatclient.GetHostConfigContext(ctx)
+	if err != nil || hostConfig.MaxOfflineLease() != 2*time.Hour {
+		t.Errorf("GetHostConfigContext = %+v, %v, want a maximum offline lease of 2h", hostConfig, err)
+	}
+	if err := lexfloatclient.RequestFloatingLicenseContext(ctx); err != nil {
+		t.Fatalf("RequestFloatingLicenseContext: %v", err)
+	}
+	if err := lexfloatclient.RequestFloatingLicenseContext(ctx); !errors.Is(err, lexfloatclient.ErrLicenseExists) {
+		t.Errorf("second RequestFloatingLicenseContext = %v, want ErrLicenseExists", err)
+	}
+	if err := lexfloatclient.IncrementFloatingClientMeterAttributeUsesContext(ctx, "exports", 3); err != nil {
+		t.Errorf("IncrementFloatingClientMeterAttributeUsesContext: %v", err)
+	}
+	if err := lexfloatclient.DecrementFloatingClientMeterAttributeUsesContext(ctx, "exports", 1); err != nil {
+		t.Errorf("DecrementFloatingClientMeterAttributeUsesContext: %v", err)
+	}
+	if uses, err := lexfloatclient.FloatingClientMeterAttributeUses("exports"); err != nil || uses != 2 {
+		t.Errorf("FloatingClientMeterAttributeUses = %d, %v, want 2", uses, err)
+	}
+	if err := lexfloatclient.ResetFloatingClientMeterAttributeUsesContext(ctx, "exports"); err != nil {
+		t.Errorf("ResetFloatingClientMeterAttributeUsesContext: %v", err)
+	}
+	if err := lexfloatclient.IncrementFloatingClientMeterAttributeUsesContext(ctx, "missing", 1); !errors.Is(err, lexfloatclient.ErrMeterAttributeNotFound) {
+		t.Errorf("IncrementFloatingClientMeterAttributeUsesContext(missing) = %v, want ErrMeterAttributeNotFound", err)
+	}
+	if err := lexfloatclient.DropFloatingLicenseContext(ctx); err != nil {
+		t.Fatalf("DropFloatingLicenseContext: %v", err)
+	}
+	if err := lexfloatclient.RequestOfflineFloatingLicenseContext(ctx, 3*3600); !errors.Is(err, lexfloatclient.ErrMaxOfflineLeaseDurationExceeded) {
+		t.Errorf("RequestOfflineFloatingLicenseContext(3h) = %v, want ErrMaxOfflineLeaseDurationExceeded", err)
+	}
+	if got := server.ActiveLeases(); got != 0 {
+		t.Errorf("ActiveLeases = %d, want 0", got)
+	}
+}
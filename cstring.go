@@ -6,24 +6,17 @@ package lexfloatclient
 import "C"
 import "unsafe"
 
-const (
-	maxCArrayLength  C.uint = 4096
-	maxGoArrayLength C.int  = 4096
-)
+// cChar is the character type of the string buffers of the library.
+type cChar = C.char
 
 func goToCString(data string) *C.char {
 	cString := C.CString(data)
 	return cString
 }
 
-func ctoGoString(cString *C.char) string {
-	goString := C.GoStringN(cString, maxGoArrayLength)
-	return goString
-}
-
-func getCArray() [maxCArrayLength]C.char {
-	var cArray [maxCArrayLength]C.char
-	return cArray
+func ctoGoString(cArray []C.char) string {
+	encodedBytes := C.GoBytes(unsafe.Pointer(&cArray[0]), C.int(len(cArray)))
+	return decodeCString(encodedBytes)
 }
 
 func freeCString(cString *C.char) {
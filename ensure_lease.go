@@ -0,0 +1,141 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// LeasePath reports how EnsureLease obtained the lease.
+type LeasePath int
+
+const (
+	// LeasePathReusedOffline means a valid offline lease, e.g. from a previous
+	// run of the process, was reused.
+	LeasePathReusedOffline LeasePath = iota
+
+	// LeasePathReusedOnline means the online lease already held by the
+	// process was reused.
+	LeasePathReusedOnline
+
+	// LeasePathRequestedOnline means a new online lease was requested.
+	LeasePathRequestedOnline
+
+	// LeasePathRequestedOffline means a new offline lease was requested.
+	LeasePathRequestedOffline
+)
+
+var leasePathNames = map[LeasePath]string{
+	LeasePathReusedOffline:    "ReusedOffline",
+	LeasePathReusedOnline:     "ReusedOnline",
+	LeasePathRequestedOnline:  "RequestedOnline",
+	LeasePathRequestedOffline: "RequestedOffline",
+}
+
+// String returns the name of the path.
+func (path LeasePath) String() string {
+	if name, ok := leasePathNames[path]; ok {
+		return name
+	}
+	return "LeasePath(" + strconv.Itoa(int(path)) + ")"
+}
+
+// EnsureLeaseOptions configures EnsureLease.
+type EnsureLeaseOptions struct {
+	// OfflineDuration, when non-zero, makes EnsureLease request an offline
+	// lease of up to this duration with RequestOfflineFloatingLicenseUpTo
+	// instead of an online lease.
+	OfflineDuration time.Duration
+
+	// ProbeHostLicenseExpiry is passed to RequestOfflineFloatingLicenseUpTo.
+	// See OfflineLeaseOptions.
+	ProbeHostLicenseExpiry bool
+
+	// MinOfflineRemaining is the time an existing offline lease must have left
+	// to be reused. A lease with less time left is dropped and a new lease is
+	// requested. Zero reuses any offline lease that has not expired.
+	MinOfflineRemaining time.Duration
+}
+
+// EnsuredLease is returned by EnsureLease.
+type EnsuredLease struct {
+	// Path reports how the lease was obtained.
+	Path LeasePath
+
+	// Lease describes the lease held after EnsureLease returned.
+	Lease LeaseInfo
+
+	// Offline describes the offline lease request, including the Granted
+	// duration, when Path is LeasePathRequestedOffline.
+	Offline OfflineLease
+}
+
+// EnsureLease makes sure the floating client holds a lease. Call it on process
+// start instead of RequestFloatingLicense, which fails with
+// LF_E_LICENSE_EXISTS when an offline lease persisted across restarts.
+//
+// A valid offline lease is reused. An online lease already held by the process
+// is reused as well, even when OfflineDuration is set. Otherwise a new online
+// or offline lease is requested, depending on OfflineDuration.
+//
+// Errors: ctx.Err() or see CurrentLeaseInfo, DropFloatingLicense, RequestFloatingLicense
+// and RequestOfflineFloatingLicenseUpTo
+func EnsureLease(ctx context.Context, options EnsureLeaseOptions) (EnsuredLease, error) {
+	leaseInfo, err := CurrentLeaseInfo()
+	if err != nil {
+		return EnsuredLease{}, err
+	}
+	if ensured, ok := reusableLease(leaseInfo, options); ok {
+		return ensured, nil
+	}
+	if leaseInfo.Leased {
+		if err := DropFloatingLicenseContext(ctx); err != nil {
+			return EnsuredLease{}, opError("DropFloatingLicense", err)
+		}
+	}
+	path := LeasePathRequestedOnline
+	var offlineLease OfflineLease
+	if options.OfflineDuration > 0 {
+		path = LeasePathRequestedOffline
+		offlineLease, err = RequestOfflineFloatingLicenseUpTo(ctx, options.OfflineDuration, OfflineLeaseOptions{
+			ProbeHostLicenseExpiry: options.ProbeHostLicenseExpiry,
+		})
+	} else {
+		err = opError("RequestFloatingLicense", RequestFloatingLicenseContext(ctx))
+	}
+	if errors.Is(err, ErrLicenseExists) {
+		// Another caller obtained a lease in the meantime.
+		if leaseInfo, infoErr := CurrentLeaseInfo(); infoErr == nil {
+			if ensured, ok := reusableLease(leaseInfo, options); ok {
+				return ensured, nil
+			}
+		}
+	}
+	if err != nil {
+		return EnsuredLease{}, err
+	}
+	leaseInfo, err = CurrentLeaseInfo()
+	if err != nil {
+		return EnsuredLease{}, err
+	}
+	return EnsuredLease{Path: path, Lease: leaseInfo, Offline: offlineLease}, nil
+}
+
+// reusableLease reports whether the lease described by leaseInfo can be reused.
+func reusableLease(leaseInfo LeaseInfo, options EnsureLeaseOptions) (EnsuredLease, bool) {
+	if !leaseInfo.Leased {
+		return EnsuredLease{}, false
+	}
+	switch leaseInfo.Mode {
+	case LeaseModeOnline:
+		return EnsuredLease{Path: LeasePathReusedOnline, Lease: leaseInfo}, true
+	case LeaseModeOffline:
+		if leaseInfo.OfflineRemaining > 0 && leaseInfo.OfflineRemaining >= options.MinOfflineRemaining {
+			return EnsuredLease{Path: LeasePathReusedOffline, Lease: leaseInfo}, true
+		}
+	}
+	return EnsuredLease{}, false
+}
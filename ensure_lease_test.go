@@ -0,0 +1,85 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestEnsureLease(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: 24 * time.Hour})
+	useBackend(t, server, server.NewBackend())
+	options := lexfloatclient.EnsureLeaseOptions{
+		OfflineDuration:     48 * time.Hour,
+		MinOfflineRemaining: time.Hour,
+	}
+	ensured, err := lexfloatclient.EnsureLease(context.Background(), options)
+	if err != nil {
+		t.Fatalf("EnsureLease: %v", err)
+	}
+	if ensured.Path != lexfloatclient.LeasePathRequestedOffline {
+		t.Errorf("Path = %v, want RequestedOffline", ensured.Path)
+	}
+	if ensured.Offline.Requested != 48*time.Hour || ensured.Offline.Granted != 24*time.Hour {
+		t.Errorf("Offline = %+v, want 48h requested and 24h granted", ensured.Offline)
+	}
+	if ensured.Lease.Mode != lexfloatclient.LeaseModeOffline {
+		t.Errorf("Lease.Mode = %q, want offline", ensured.Lease.Mode)
+	}
+
+	ensured, err = lexfloatclient.EnsureLease(context.Background(), options)
+	if err != nil {
+		t.Fatalf("second EnsureLease: %v", err)
+	}
+	if ensured.Path != lexfloatclient.LeasePathReusedOffline {
+		t.Errorf("second Path = %v, want ReusedOffline", ensured.Path)
+	}
+	if got := server.Calls(lexfloattest.OpRequestOfflineFloatingLicense); got != 1 {
+		t.Errorf("RequestOfflineFloatingLicense calls = %d, want 1", got)
+	}
+}
+
+// racingBackend simulates another caller that obtains a short offline lease
+// while RequestFloatingLicense is in flight.
+type racingBackend struct {
+	*lexfloattest.Backend
+}
+
+func (backend racingBackend) RequestFloatingLicense() int {
+	if status := backend.Backend.RequestOfflineFloatingLicense(60); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lexfloatclient.LF_E_LICENSE_EXISTS
+}
+
+func TestEnsureLeaseLicenseExists(t *testing.T) {
+	tests := []struct {
+		name                string
+		minOfflineRemaining time.Duration
+		wantErr             error
+	}{
+		{name: "reused"},
+		{name: "too short", minOfflineRemaining: time.Hour, wantErr: lexfloatclient.ErrLicenseExists},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: time.Hour})
+			useBackend(t, server, racingBackend{server.NewBackend()})
+			ensured, err := lexfloatclient.EnsureLease(context.Background(), lexfloatclient.EnsureLeaseOptions{
+				MinOfflineRemaining: test.minOfflineRemaining,
+			})
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("EnsureLease = %v, want %v", err, test.wantErr)
+			}
+			if err == nil && ensured.Path != lexfloatclient.LeasePathReusedOffline {
+				t.Errorf("Path = %v, want ReusedOffline", ensured.Path)
+			}
+		})
+	}
+}
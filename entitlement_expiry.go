@@ -0,0 +1,206 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// EntitlementExpiryEvent is passed to ExpirySchedulerOptions.OnExpiry when a
+// lead time before the expiry of a feature entitlement has been reached.
+type EntitlementExpiryEvent struct {
+	// Entitlement is the feature entitlement that expires.
+	Entitlement HostFeatureEntitlement
+
+	// Expiry is the expiry date of the feature entitlement.
+	Expiry time.Time
+
+	// Lead is the lead time that has been reached. Zero means the feature
+	// entitlement has expired.
+	Lead time.Duration
+}
+
+// ExpirySchedulerOptions configures an ExpiryScheduler.
+type ExpirySchedulerOptions struct {
+	// LeadTimes are the times before the expiry of a feature entitlement at
+	// which OnExpiry is invoked, e.g. 7 days, 1 day and 0 for the expiry
+	// itself. Negative lead times are ignored. Default: 0.
+	LeadTimes []time.Duration
+
+	// OnExpiry is invoked on a separate goroutine when a lead time is reached.
+	OnExpiry func(event EntitlementExpiryEvent)
+}
+
+// expiryKey identifies one lead time of one expiry date of a feature.
+type expiryKey struct {
+	featureName string
+	expiresAt   int64
+	lead        time.Duration
+}
+
+// ExpiryScheduler invokes a callback at lead times before feature entitlements
+// expire. Each lead time fires once per feature and expiry date; when the
+// expiry date changes, the lead times of the new date are scheduled.
+//
+// When a scheduler learns of a feature entitlement after some of its lead
+// times have passed, only the shortest passed lead time fires, immediately.
+type ExpiryScheduler struct {
+	options ExpirySchedulerOptions
+	leads   []time.Duration
+
+	mutex       sync.Mutex
+	timers      map[expiryKey]*time.Timer
+	fired       map[expiryKey]bool
+	unsubscribe func()
+	stopped     bool
+}
+
+// NewExpiryScheduler returns an ExpiryScheduler without feature entitlements.
+func NewExpiryScheduler(options ExpirySchedulerOptions) *ExpiryScheduler {
+	leadSet := make(map[time.Duration]bool)
+	for _, lead := range options.LeadTimes {
+		if lead >= 0 {
+			leadSet[lead] = true
+		}
+	}
+	if len(leadSet) == 0 {
+		leadSet[0] = true
+	}
+	leads := make([]time.Duration, 0, len(leadSet))
+	for lead := range leadSet {
+		leads = append(leads, lead)
+	}
+	sort.Slice(leads, func(i, j int) bool { return leads[i] < leads[j] })
+	return &ExpiryScheduler{
+		options: options,
+		leads:   leads,
+		timers:  make(map[expiryKey]*time.Timer),
+		fired:   make(map[expiryKey]bool),
+	}
+}
+
+// Start schedules the feature entitlements of the LexFloatServer license and
+// reschedules them after every successful lease renewal.
+//
+// Errors: see HostFeatureEntitlements
+func (scheduler *ExpiryScheduler) Start() error {
+	hostFeatureEntitlements, err := HostFeatureEntitlements()
+	if err != nil {
+		return err
+	}
+	scheduler.Update(hostFeatureEntitlements)
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if scheduler.unsubscribe == nil && !scheduler.stopped {
+		scheduler.unsubscribe = Subscribe(scheduler.renewed)
+	}
+	return nil
+}
+
+// renewed is subscribed to the renew statuses.
+func (scheduler *ExpiryScheduler) renewed(status int) {
+	if status != LF_OK {
+		return
+	}
+	if hostFeatureEntitlements, err := HostFeatureEntitlements(); err == nil {
+		scheduler.Update(hostFeatureEntitlements)
+	}
+}
+
+// Update replaces the scheduled feature entitlements. Lead times of feature
+// entitlements that are no longer present or whose expiry date has changed
+// are cancelled. It can be used with an EntitlementWatcher instead of Start.
+//
+// Parameters:
+// - hostFeatureEntitlements: the current feature entitlements
+func (scheduler *ExpiryScheduler) Update(hostFeatureEntitlements []HostFeatureEntitlement) {
+	now := currentTime()
+	var due []EntitlementExpiryEvent
+	scheduler.mutex.Lock()
+	if scheduler.stopped {
+		scheduler.mutex.Unlock()
+		return
+	}
+	current := make(map[expiryKey]bool)
+	for _, hostFeatureEntitlement := range hostFeatureEntitlements {
+		expiry := hostFeatureEntitlement.ExpiryTime()
+		if expiry.IsZero() {
+			continue
+		}
+		passed := false
+		for _, lead := range scheduler.leads {
+			key := expiryKey{hostFeatureEntitlement.FeatureName, hostFeatureEntitlement.ExpiresAt, lead}
+			current[key] = true
+			if scheduler.fired[key] || scheduler.timers[key] != nil {
+				passed = passed || scheduler.fired[key]
+				continue
+			}
+			event := EntitlementExpiryEvent{Entitlement: hostFeatureEntitlement, Expiry: expiry, Lead: lead}
+			fireAt := expiry.Add(-lead)
+			if fireAt.After(now) {
+				scheduler.timers[key] = time.AfterFunc(fireAt.Sub(now), func() {
+					scheduler.fire(key, event)
+				})
+				continue
+			}
+			// Leads are sorted ascending, so only the shortest passed lead
+			// fires; longer passed leads are only marked as fired.
+			if !passed {
+				due = append(due, event)
+			}
+			scheduler.fired[key] = true
+			passed = true
+		}
+	}
+	for key, timer := range scheduler.timers {
+		if !current[key] {
+			timer.Stop()
+			delete(scheduler.timers, key)
+		}
+	}
+	for key := range scheduler.fired {
+		if !current[key] {
+			delete(scheduler.fired, key)
+		}
+	}
+	scheduler.mutex.Unlock()
+	for _, event := range due {
+		go scheduler.invoke(event)
+	}
+}
+
+func (scheduler *ExpiryScheduler) fire(key expiryKey, event EntitlementExpiryEvent) {
+	scheduler.mutex.Lock()
+	if scheduler.stopped || scheduler.timers[key] == nil {
+		scheduler.mutex.Unlock()
+		return
+	}
+	delete(scheduler.timers, key)
+	scheduler.fired[key] = true
+	scheduler.mutex.Unlock()
+	scheduler.invoke(event)
+}
+
+func (scheduler *ExpiryScheduler) invoke(event EntitlementExpiryEvent) {
+	if scheduler.options.OnExpiry != nil {
+		scheduler.options.OnExpiry(event)
+	}
+}
+
+// Stop cancels all lead times and stops watching the lease renewals. A stopped
+// scheduler cannot be restarted.
+func (scheduler *ExpiryScheduler) Stop() {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	scheduler.stopped = true
+	if scheduler.unsubscribe != nil {
+		scheduler.unsubscribe()
+		scheduler.unsubscribe = nil
+	}
+	for key, timer := range scheduler.timers {
+		timer.Stop()
+		delete(scheduler.timers, key)
+	}
+}
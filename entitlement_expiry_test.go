@@ -0,0 +1,153 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// newExpiryScheduler returns a scheduler that sends its events to the returned
+// channel.
+func newExpiryScheduler(t *testing.T, leads ...time.Duration) (*lexfloatclient.ExpiryScheduler, chan lexfloatclient.EntitlementExpiryEvent) {
+	events := make(chan lexfloatclient.EntitlementExpiryEvent, 16)
+	scheduler := lexfloatclient.NewExpiryScheduler(lexfloatclient.ExpirySchedulerOptions{
+		LeadTimes: leads,
+		OnExpiry: func(event lexfloatclient.EntitlementExpiryEvent) {
+			events <- event
+		},
+	})
+	t.Cleanup(scheduler.Stop)
+	return scheduler, events
+}
+
+func expiringEntitlement(name string, expiry time.Time) lexfloatclient.HostFeatureEntitlement {
+	return lexfloatclient.HostFeatureEntitlement{FeatureName: name, Value: "1", ExpiresAt: expiry.Unix()}
+}
+
+// receiveEvent waits up to a second for an event.
+func receiveEvent(t *testing.T, events chan lexfloatclient.EntitlementExpiryEvent) lexfloatclient.EntitlementExpiryEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for an expiry event")
+		return lexfloatclient.EntitlementExpiryEvent{}
+	}
+}
+
+// expectNoEvent fails the test if an event arrives within d.
+func expectNoEvent(t *testing.T, events chan lexfloatclient.EntitlementExpiryEvent, d time.Duration) {
+	t.Helper()
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event for %q with lead %v", event.Entitlement.FeatureName, event.Lead)
+	case <-time.After(d):
+	}
+}
+
+// installClock installs the virtual clock of a new server for the test.
+func installClock(t *testing.T) *lexfloattest.Server {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	return server
+}
+
+func TestExpirySchedulerLeadTimes(t *testing.T) {
+	server := installClock(t)
+	expiry := server.Now().Add(time.Hour)
+	// The first two lead times are reached after 20 and 40 milliseconds.
+	early, late := time.Hour-20*time.Millisecond, time.Hour-40*time.Millisecond
+	scheduler, events := newExpiryScheduler(t, 0, late, early, -time.Minute)
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{
+		expiringEntitlement("export", expiry),
+		{FeatureName: "forever", Value: "1"},
+	})
+	for _, want := range []time.Duration{early, late} {
+		event := receiveEvent(t, events)
+		if event.Lead != want || event.Entitlement.FeatureName != "export" || !event.Expiry.Equal(expiry) {
+			t.Errorf("event = %+v, want lead %v for export expiring at %v", event, want, expiry)
+		}
+	}
+	expectNoEvent(t, events, 50*time.Millisecond)
+}
+
+func TestExpirySchedulerPassedLeadTimes(t *testing.T) {
+	server := installClock(t)
+	scheduler, events := newExpiryScheduler(t, 0, 30*time.Minute, time.Hour, 2*time.Hour)
+	entitlements := []lexfloatclient.HostFeatureEntitlement{
+		expiringEntitlement("export", server.Now().Add(45*time.Minute)),
+	}
+	scheduler.Update(entitlements)
+	if event := receiveEvent(t, events); event.Lead != time.Hour {
+		t.Errorf("Lead = %v, want 1h, the shortest lead time that has passed", event.Lead)
+	}
+	// Updating with the same expiry date does not fire the lead times again.
+	scheduler.Update(entitlements)
+	expectNoEvent(t, events, 50*time.Millisecond)
+}
+
+func TestExpirySchedulerUpdate(t *testing.T) {
+	server := installClock(t)
+	lead := time.Hour - 30*time.Millisecond
+	scheduler, events := newExpiryScheduler(t, lead)
+	first := server.Now().Add(time.Hour)
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("export", first)})
+	// The expiry date moves before the lead time is reached: the lead time of
+	// the first date is cancelled and the one of the second date is scheduled.
+	second := first.Add(time.Hour)
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("export", second)})
+	expectNoEvent(t, events, 80*time.Millisecond)
+	third := server.Now().Add(time.Hour)
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{
+		expiringEntitlement("export", third),
+		expiringEntitlement("audit", third),
+	})
+	// A removed feature entitlement is cancelled too.
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("export", third)})
+	event := receiveEvent(t, events)
+	if event.Entitlement.FeatureName != "export" || !event.Expiry.Equal(third) {
+		t.Errorf("event = %+v, want export expiring at %v", event, third)
+	}
+	expectNoEvent(t, events, 50*time.Millisecond)
+}
+
+func TestExpirySchedulerRenewed(t *testing.T) {
+	server := leasedServer(t, lexfloattest.Config{})
+	lead := time.Hour - 20*time.Millisecond
+	scheduler, events := newExpiryScheduler(t, lead)
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	expiry := server.Now().Add(15*time.Minute + time.Hour)
+	server.SetEntitlements([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("export", expiry)})
+	expectNoEvent(t, events, 50*time.Millisecond)
+	// The lead time is scheduled once the next renewal reports the new
+	// feature entitlement.
+	server.Advance(15 * time.Minute)
+	lexfloatclient.WaitForCallbacks()
+	if event := receiveEvent(t, events); !event.Expiry.Equal(expiry) {
+		t.Errorf("Expiry = %v, want %v", event.Expiry, expiry)
+	}
+}
+
+func TestExpirySchedulerStop(t *testing.T) {
+	server := leasedServer(t, lexfloattest.Config{})
+	scheduler, events := newExpiryScheduler(t, time.Hour-20*time.Millisecond)
+	if err := scheduler.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("export", server.Now().Add(time.Hour))})
+	scheduler.Stop()
+	expectNoEvent(t, events, 60*time.Millisecond)
+	// A stopped scheduler ignores renewals and updates.
+	server.SetEntitlements([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("audit", server.Now())})
+	server.Advance(15 * time.Minute)
+	lexfloatclient.WaitForCallbacks()
+	scheduler.Update([]lexfloatclient.HostFeatureEntitlement{expiringEntitlement("audit", server.Now())})
+	expectNoEvent(t, events, 20*time.Millisecond)
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EntitlementValueError is returned when the value of a feature entitlement
+// cannot be parsed as the requested type.
+type EntitlementValueError struct {
+	// FeatureName is the name of the feature.
+	FeatureName string
+
+	// Value is the raw value of the feature entitlement.
+	Value string
+
+	// Type is the requested type, e.g. "bool" or "int64".
+	Type string
+
+	// Err is the parse error, if any.
+	Err error
+}
+
+func (err *EntitlementValueError) Error() string {
+	message := fmt.Sprintf("lexfloatclient: feature entitlement %q: cannot parse value %q as %s", err.FeatureName, err.Value, err.Type)
+	if err.Err != nil {
+		message += ": " + err.Err.Error()
+	}
+	return message
+}
+
+func (err *EntitlementValueError) Unwrap() error {
+	return err.Err
+}
+
+func (hostFeatureEntitlement HostFeatureEntitlement) valueError(valueType string, err error) error {
+	return &EntitlementValueError{
+		FeatureName: hostFeatureEntitlement.FeatureName,
+		Value:       hostFeatureEntitlement.Value,
+		Type:        valueType,
+		Err:         err,
+	}
+}
+
+// value returns the value of the feature entitlement without surrounding white space.
+func (hostFeatureEntitlement HostFeatureEntitlement) value() string {
+	return strings.TrimSpace(hostFeatureEntitlement.Value)
+}
+
+// Expired reports whether the feature entitlement has expired at the given time.
+func (hostFeatureEntitlement HostFeatureEntitlement) Expired(now time.Time) bool {
+	expiry := hostFeatureEntitlement.ExpiryTime()
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+// Bool parses the value as a boolean. It accepts the values accepted by
+// strconv.ParseBool, ignoring case, as well as "yes", "no", "on" and "off".
+//
+// Errors: *EntitlementValueError
+func (hostFeatureEntitlement HostFeatureEntitlement) Bool() (bool, error) {
+	switch value := strings.ToLower(hostFeatureEntitlement.value()); value {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	default:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, hostFeatureEntitlement.valueError("bool", unwrapNumError(err))
+		}
+		return enabled, nil
+	}
+}
+
+// Int64 parses the value as a base 10 integer.
+//
+// Errors: *EntitlementValueError
+func (hostFeatureEntitlement HostFeatureEntitlement) Int64() (int64, error) {
+	value, err := strconv.ParseInt(hostFeatureEntitlement.value(), 10, 64)
+	if err != nil {
+		return 0, hostFeatureEntitlement.valueError("int64", unwrapNumError(err))
+	}
+	return value, nil
+}
+
+// Float64 parses the value as a floating-point number.
+//
+// Errors: *EntitlementValueError
+func (hostFeatureEntitlement HostFeatureEntitlement) Float64() (float64, error) {
+	value, err := strconv.ParseFloat(hostFeatureEntitlement.value(), 64)
+	if err != nil {
+		return 0, hostFeatureEntitlement.valueError("float64", unwrapNumError(err))
+	}
+	return value, nil
+}
+
+// Duration parses the value as a duration. It accepts the values accepted by
+// time.ParseDuration as well as a number of days or weeks, e.g. "30d" or "2w".
+// Like time.ParseDuration, it rejects durations that do not fit in a
+// time.Duration.
+//
+// Errors: *EntitlementValueError
+func (hostFeatureEntitlement HostFeatureEntitlement) Duration() (time.Duration, error) {
+	value := hostFeatureEntitlement.value()
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if !strings.HasSuffix(value, suffix) {
+			continue
+		}
+		count, err := strconv.ParseFloat(strings.TrimSuffix(value, suffix), 64)
+		if err != nil {
+			return 0, hostFeatureEntitlement.valueError("duration", unwrapNumError(err))
+		}
+		if math.IsNaN(count) {
+			return 0, hostFeatureEntitlement.valueError("duration", strconv.ErrSyntax)
+		}
+		// float64(math.MaxInt64) rounds up to 2^63, which is out of range too.
+		nanoseconds := count * float64(unit)
+		if nanoseconds >= float64(math.MaxInt64) || nanoseconds < float64(math.MinInt64) {
+			return 0, hostFeatureEntitlement.valueError("duration", strconv.ErrRange)
+		}
+		return time.Duration(nanoseconds), nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, hostFeatureEntitlement.valueError("duration", err)
+	}
+	return duration, nil
+}
+
+// Enum returns the value if it is one of the allowed values.
+//
+// Parameters:
+// - allowed: the allowed values, compared case-sensitively
+//
+// Errors: *EntitlementValueError
+func (hostFeatureEntitlement HostFeatureEntitlement) Enum(allowed ...string) (string, error) {
+	value := hostFeatureEntitlement.value()
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
+			return value, nil
+		}
+	}
+	return "", hostFeatureEntitlement.valueError("one of "+strings.Join(allowed, ", "), nil)
+}
+
+// unwrapNumError drops the function name and input repeated by strconv.NumError.
+func unwrapNumError(err error) error {
+	var numError *strconv.NumError
+	if errors.As(err, &numError) {
+		return numError.Err
+	}
+	return err
+}
+
+// activeFeatureEntitlement returns the feature entitlement with the given name
+// unless it does not exist or has expired.
+func activeFeatureEntitlement(name string) (HostFeatureEntitlement, bool, error) {
+	hostFeatureEntitlement, err := HostFeatureEntitlementByName(name)
+	if errors.Is(err, ErrFeatureEntitlementNotFound) {
+		return HostFeatureEntitlement{}, false, nil
+	}
+	if err != nil {
+		return HostFeatureEntitlement{}, false, err
+	}
+	if hostFeatureEntitlement.Expired(currentTime()) {
+		return HostFeatureEntitlement{}, false, nil
+	}
+	return hostFeatureEntitlement, true, nil
+}
+
+// FeatureEnabled reports whether the feature entitlement with the given name
+// is enabled. A feature entitlement that does not exist or has expired is
+// reported as disabled.
+//
+// Parameters:
+// - name: name of the feature
+//
+// Errors: *EntitlementValueError or see HostFeatureEntitlementByName
+func FeatureEnabled(name string) (bool, error) {
+	hostFeatureEntitlement, ok, err := activeFeatureEntitlement(name)
+	if !ok {
+		return false, err
+	}
+	return hostFeatureEntitlement.Bool()
+}
+
+// FeatureLimit returns the integer value of the feature entitlement with the
+// given name.
+//
+// Parameters:
+// - name: name of the feature
+//
+// Returns: the limit, and false if the feature entitlement does not exist or has expired
+//
+// Errors: *EntitlementValueError or see HostFeatureEntitlementByName
+func FeatureLimit(name string) (int64, bool, error) {
+	hostFeatureEntitlement, ok, err := activeFeatureEntitlement(name)
+	if !ok {
+		return 0, false, err
+	}
+	limit, err := hostFeatureEntitlement.Int64()
+	if err != nil {
+		return 0, false, err
+	}
+	return limit, true, nil
+}
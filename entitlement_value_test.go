@@ -0,0 +1,172 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func entitlement(value string) HostFeatureEntitlement {
+	return HostFeatureEntitlement{FeatureName: "feature", Value: value}
+}
+
+// checkValueError fails the test unless err is an *EntitlementValueError for
+// the given value and type.
+func checkValueError(t *testing.T, err error, value string, valueType string) {
+	t.Helper()
+	var valueError *EntitlementValueError
+	if !errors.As(err, &valueError) {
+		t.Errorf("%q: error = %v, want *EntitlementValueError", value, err)
+		return
+	}
+	if valueError.FeatureName != "feature" || valueError.Value != value || valueError.Type != valueType {
+		t.Errorf("%q: error = %+v, want feature %q, value %q and type %q", value, valueError, "feature", value, valueType)
+	}
+}
+
+func TestEntitlementBool(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    bool
+		wantErr bool
+	}{
+		{value: "true", want: true},
+		{value: " TRUE ", want: true},
+		{value: "1", want: true},
+		{value: "yes", want: true},
+		{value: "On", want: true},
+		{value: "false"},
+		{value: "0"},
+		{value: "no"},
+		{value: "OFF"},
+		{value: "", wantErr: true},
+		{value: "maybe", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := entitlement(test.value).Bool()
+		if test.wantErr {
+			checkValueError(t, err, test.value, "bool")
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("%q: Bool() = %v, %v, want %v", test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestEntitlementInt64(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr error
+	}{
+		{value: "42", want: 42},
+		{value: " -7 ", want: -7},
+		{value: "9223372036854775807", want: 9223372036854775807},
+		{value: "9223372036854775808", wantErr: strconv.ErrRange},
+		{value: "1.5", wantErr: strconv.ErrSyntax},
+		{value: "", wantErr: strconv.ErrSyntax},
+	}
+	for _, test := range tests {
+		got, err := entitlement(test.value).Int64()
+		if test.wantErr != nil {
+			checkValueError(t, err, test.value, "int64")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("%q: error = %v, want %v", test.value, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("%q: Int64() = %v, %v, want %v", test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestEntitlementFloat64(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr error
+	}{
+		{value: "1.5", want: 1.5},
+		{value: " -2 ", want: -2},
+		{value: "1e3", want: 1000},
+		{value: "1e400", wantErr: strconv.ErrRange},
+		{value: "abc", wantErr: strconv.ErrSyntax},
+	}
+	for _, test := range tests {
+		got, err := entitlement(test.value).Float64()
+		if test.wantErr != nil {
+			checkValueError(t, err, test.value, "float64")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("%q: error = %v, want %v", test.value, err, test.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("%q: Float64() = %v, %v, want %v", test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestEntitlementDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "90m", want: 90 * time.Minute},
+		{value: " 1h30m ", want: 90 * time.Minute},
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "1.5d", want: 36 * time.Hour},
+		{value: "2w", want: 14 * 24 * time.Hour},
+		{value: "-1d", want: -24 * time.Hour},
+		{value: "106751d", want: 106751 * 24 * time.Hour},
+		{value: "106752d", wantErr: true},
+		{value: "1e10d", wantErr: true},
+		{value: "-1e10w", wantErr: true},
+		{value: "NaNd", wantErr: true},
+		{value: "infw", wantErr: true},
+		{value: "-Infd", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "30", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := entitlement(test.value).Duration()
+		if test.wantErr {
+			checkValueError(t, err, test.value, "duration")
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("%q: Duration() = %v, %v, want %v", test.value, got, err, test.want)
+		}
+	}
+}
+
+func TestEntitlementEnum(t *testing.T) {
+	allowed := []string{"basic", "pro"}
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: "basic", want: "basic"},
+		{value: " pro ", want: "pro"},
+		{value: "Pro", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := entitlement(test.value).Enum(allowed...)
+		if test.wantErr {
+			checkValueError(t, err, test.value, "one of basic, pro")
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("%q: Enum() = %q, %v, want %q", test.value, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// EntitlementSnapshot holds the entitlements of the LexFloatServer license at
+// one point in time.
+type EntitlementSnapshot struct {
+	// SetName, SetDisplayName and SetTier describe the entitlement set. They
+	// are empty if no entitlement set is linked to the license.
+	SetName        string
+	SetDisplayName string
+	SetTier        int64
+
+	// Entitlements maps feature names to feature entitlements.
+	Entitlements map[string]HostFeatureEntitlement
+
+	// LicenseMetadata maps the watched license metadata keys to their values.
+	// Keys that do not exist are absent.
+	LicenseMetadata map[string]string
+}
+
+// TakeEntitlementSnapshot reads the entitlement set, the feature entitlements
+// and the license metadata fields with the given keys.
+//
+// Parameters:
+// - metadataKeys: keys of the license metadata fields to read
+//
+// Errors: see HostLicenseEntitlementSetName, HostFeatureEntitlements and HostLicenseMetadata
+func TakeEntitlementSnapshot(metadataKeys ...string) (EntitlementSnapshot, error) {
+	snapshot := EntitlementSnapshot{
+		Entitlements:    make(map[string]HostFeatureEntitlement),
+		LicenseMetadata: make(map[string]string),
+	}
+	var err error
+	if snapshot.SetName, err = HostLicenseEntitlementSetName(); err == nil {
+		if snapshot.SetDisplayName, err = HostLicenseEntitlementSetDisplayName(); err != nil {
+			return EntitlementSnapshot{}, err
+		}
+		if snapshot.SetTier, err = HostLicenseEntitlementSetTier(); err != nil {
+			return EntitlementSnapshot{}, err
+		}
+	} else if !errors.Is(err, ErrEntitlementSetNotLinked) {
+		return EntitlementSnapshot{}, err
+	}
+	hostFeatureEntitlements, err := HostFeatureEntitlements()
+	if err != nil {
+		return EntitlementSnapshot{}, err
+	}
+	for _, hostFeatureEntitlement := range hostFeatureEntitlements {
+		snapshot.Entitlements[hostFeatureEntitlement.FeatureName] = hostFeatureEntitlement
+	}
+	for _, key := range metadataKeys {
+		value, err := HostLicenseMetadata(key)
+		if errors.Is(err, ErrMetadataKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return EntitlementSnapshot{}, err
+		}
+		snapshot.LicenseMetadata[key] = value
+	}
+	return snapshot, nil
+}
+
+// EntitlementChangeKind classifies an EntitlementChange.
+type EntitlementChangeKind int
+
+const (
+	// EntitlementAdded means a feature entitlement has been added.
+	EntitlementAdded EntitlementChangeKind = iota
+
+	// EntitlementRemoved means a feature entitlement has been removed.
+	EntitlementRemoved
+
+	// EntitlementValueChanged means the value or base value of a feature
+	// entitlement has changed.
+	EntitlementValueChanged
+
+	// EntitlementExpiryChanged means the expiry date of a feature entitlement
+	// has changed.
+	EntitlementExpiryChanged
+
+	// EntitlementSetChanged means the name, display name or tier of the
+	// entitlement set has changed.
+	EntitlementSetChanged
+
+	// LicenseMetadataChanged means a watched license metadata field has been
+	// added, removed or changed.
+	LicenseMetadataChanged
+)
+
+var entitlementChangeKindNames = map[EntitlementChangeKind]string{
+	EntitlementAdded:         "EntitlementAdded",
+	EntitlementRemoved:       "EntitlementRemoved",
+	EntitlementValueChanged:  "EntitlementValueChanged",
+	EntitlementExpiryChanged: "EntitlementExpiryChanged",
+	EntitlementSetChanged:    "EntitlementSetChanged",
+	LicenseMetadataChanged:   "LicenseMetadataChanged",
+}
+
+// String returns the name of the kind.
+func (kind EntitlementChangeKind) String() string {
+	if name, ok := entitlementChangeKindNames[kind]; ok {
+		return name
+	}
+	return "EntitlementChangeKind(" + strconv.Itoa(int(kind)) + ")"
+}
+
+// EntitlementChange describes a difference between two entitlement snapshots.
+type EntitlementChange struct {
+	Kind EntitlementChangeKind
+
+	// Name is the feature name, or the license metadata key for
+	// LicenseMetadataChanged. It is empty for EntitlementSetChanged.
+	Name string
+
+	// Old and New are the feature entitlement before and after the change.
+	// Old is zero for EntitlementAdded and New is zero for EntitlementRemoved.
+	Old HostFeatureEntitlement
+	New HostFeatureEntitlement
+
+	// OldValue and NewValue are the license metadata values before and after
+	// a LicenseMetadataChanged change. They are empty if the field is absent.
+	OldValue string
+	NewValue string
+}
+
+// DiffEntitlements returns the changes from previous to current, ordered by
+// kind and name.
+func DiffEntitlements(previous EntitlementSnapshot, current EntitlementSnapshot) []EntitlementChange {
+	var changes []EntitlementChange
+	if previous.SetName != current.SetName || previous.SetDisplayName != current.SetDisplayName || previous.SetTier != current.SetTier {
+		changes = append(changes, EntitlementChange{Kind: EntitlementSetChanged})
+	}
+	for name, oldEntitlement := range previous.Entitlements {
+		if _, ok := current.Entitlements[name]; !ok {
+			changes = append(changes, EntitlementChange{Kind: EntitlementRemoved, Name: name, Old: oldEntitlement})
+		}
+	}
+	for name, newEntitlement := range current.Entitlements {
+		oldEntitlement, ok := previous.Entitlements[name]
+		if !ok {
+			changes = append(changes, EntitlementChange{Kind: EntitlementAdded, Name: name, New: newEntitlement})
+			continue
+		}
+		if oldEntitlement.Value != newEntitlement.Value || oldEntitlement.BaseValue != newEntitlement.BaseValue {
+			changes = append(changes, EntitlementChange{Kind: EntitlementValueChanged, Name: name, Old: oldEntitlement, New: newEntitlement})
+		}
+		if oldEntitlement.ExpiresAt != newEntitlement.ExpiresAt {
+			changes = append(changes, EntitlementChange{Kind: EntitlementExpiryChanged, Name: name, Old: oldEntitlement, New: newEntitlement})
+		}
+	}
+	keys := make(map[string]bool)
+	for key := range previous.LicenseMetadata {
+		keys[key] = true
+	}
+	for key := range current.LicenseMetadata {
+		keys[key] = true
+	}
+	for key := range keys {
+		oldValue, oldOk := previous.LicenseMetadata[key]
+		newValue, newOk := current.LicenseMetadata[key]
+		if oldValue != newValue || oldOk != newOk {
+			changes = append(changes, EntitlementChange{Kind: LicenseMetadataChanged, Name: key, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// EntitlementWatcherOptions configures an EntitlementWatcher.
+type EntitlementWatcherOptions struct {
+	// MetadataKeys are the keys of the license metadata fields to watch.
+	MetadataKeys []string
+
+	// OnChange is invoked with the previous and current snapshots and the
+	// changes between them whenever a snapshot differs from the previous one.
+	OnChange func(previous EntitlementSnapshot, current EntitlementSnapshot, changes []EntitlementChange)
+
+	// OnError is invoked when a snapshot cannot be taken. It may be nil.
+	OnError func(err error)
+}
+
+// EntitlementWatcher detects changes of the entitlements of the LexFloatServer
+// license. It takes a snapshot after every successful lease renewal and
+// compares it with the previous one.
+//
+// The callbacks run on the goroutine that dispatches the renew statuses, see
+// SetFloatingLicenseCallback.
+type EntitlementWatcher struct {
+	options EntitlementWatcherOptions
+
+	// checkMutex serializes taking, swapping and diffing snapshots, so the
+	// renew listener and Check report changes in order.
+	checkMutex sync.Mutex
+
+	mutex       sync.Mutex
+	snapshot    *EntitlementSnapshot
+	unsubscribe func()
+}
+
+// NewEntitlementWatcher returns an EntitlementWatcher that is not started.
+func NewEntitlementWatcher(options EntitlementWatcherOptions) *EntitlementWatcher {
+	return &EntitlementWatcher{options: options}
+}
+
+// Start takes the first snapshot, if the floating client holds a lease, and
+// starts watching the lease renewals. The first snapshot is not reported as
+// changes. Starting a started watcher does nothing.
+//
+// Errors: see TakeEntitlementSnapshot
+func (watcher *EntitlementWatcher) Start() error {
+	watcher.checkMutex.Lock()
+	defer watcher.checkMutex.Unlock()
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+	if watcher.unsubscribe != nil {
+		return nil
+	}
+	if HasFloatingLicense() == LF_OK {
+		snapshot, err := TakeEntitlementSnapshot(watcher.options.MetadataKeys...)
+		if err != nil {
+			return err
+		}
+		watcher.snapshot = &snapshot
+	}
+	watcher.unsubscribe = Subscribe(watcher.renewed)
+	return nil
+}
+
+// Stop stops watching the lease renewals.
+func (watcher *EntitlementWatcher) Stop() {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+	if watcher.unsubscribe != nil {
+		watcher.unsubscribe()
+		watcher.unsubscribe = nil
+	}
+}
+
+// Snapshot returns the last snapshot, and false if none has been taken yet.
+func (watcher *EntitlementWatcher) Snapshot() (EntitlementSnapshot, bool) {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+	if watcher.snapshot == nil {
+		return EntitlementSnapshot{}, false
+	}
+	return *watcher.snapshot, true
+}
+
+// Check takes a snapshot immediately and reports the changes since the
+// previous one, e.g. after the lease has been requested. Checks, including
+// those after lease renewals, run one at a time, so OnChange must not call
+// Check.
+//
+// Errors: see TakeEntitlementSnapshot
+func (watcher *EntitlementWatcher) Check() error {
+	watcher.checkMutex.Lock()
+	defer watcher.checkMutex.Unlock()
+	snapshot, err := TakeEntitlementSnapshot(watcher.options.MetadataKeys...)
+	if err != nil {
+		return err
+	}
+	watcher.mutex.Lock()
+	previous := watcher.snapshot
+	watcher.snapshot = &snapshot
+	watcher.mutex.Unlock()
+	if previous == nil || watcher.options.OnChange == nil {
+		return nil
+	}
+	if changes := DiffEntitlements(*previous, snapshot); len(changes) > 0 {
+		watcher.options.OnChange(*previous, snapshot, changes)
+	}
+	return nil
+}
+
+// renewed is subscribed to the renew statuses.
+func (watcher *EntitlementWatcher) renewed(status int) {
+	if status != LF_OK {
+		return
+	}
+	if err := watcher.Check(); err != nil && watcher.options.OnError != nil {
+		watcher.options.OnError(err)
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func seats(value int) []lexfloatclient.HostFeatureEntitlement {
+	return []lexfloatclient.HostFeatureEntitlement{
+		{FeatureName: "seats", Value: strconv.Itoa(value)},
+	}
+}
+
+// slowBackend delays every other read of the feature entitlements, so that a
+// snapshot taken earlier would be reported after one taken later unless the
+// checks are serialized.
+type slowBackend struct {
+	*lexfloattest.Backend
+	calls *int32
+}
+
+func (backend slowBackend) GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson *string) int {
+	status := backend.Backend.GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson)
+	if atomic.AddInt32(backend.calls, 1)%2 == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	return status
+}
+
+// TestEntitlementWatcherOrder runs Check concurrently with the renew listener
+// while the entitlements change and verifies that the reported changes form
+// an unbroken, increasing sequence.
+func TestEntitlementWatcherOrder(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{Entitlements: seats(0)})
+	useBackend(t, server, slowBackend{server.NewBackend(), new(int32)})
+	if err := lexfloatclient.RequestFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("RequestFloatingLicenseContext: %v", err)
+	}
+	var (
+		mutex   sync.Mutex
+		reports [][2]int
+	)
+	value := func(snapshot lexfloatclient.EntitlementSnapshot) int {
+		seats, _ := snapshot.Entitlements["seats"].Int64()
+		return int(seats)
+	}
+	watcher := lexfloatclient.NewEntitlementWatcher(lexfloatclient.EntitlementWatcherOptions{
+		OnChange: func(previous lexfloatclient.EntitlementSnapshot, current lexfloatclient.EntitlementSnapshot, changes []lexfloatclient.EntitlementChange) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			reports = append(reports, [2]int{value(previous), value(current)})
+		},
+		OnError: func(err error) {
+			t.Errorf("OnError: %v", err)
+		},
+	})
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer watcher.Stop()
+	const rounds = 100
+	var checks sync.WaitGroup
+	for round := 1; round <= rounds; round++ {
+		server.SetEntitlements(seats(round))
+		checks.Add(1)
+		go func() {
+			defer checks.Done()
+			if err := watcher.Check(); err != nil {
+				t.Errorf("Check: %v", err)
+			}
+		}()
+		server.Advance(15 * time.Minute)
+		lexfloatclient.WaitForCallbacks()
+	}
+	checks.Wait()
+	if err := watcher.Check(); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	last := 0
+	for i, report := range reports {
+		if report[0] != last || report[1] <= report[0] {
+			t.Fatalf("change %d = %v after %d: %v", i, report, last, reports)
+		}
+		last = report[1]
+	}
+	if last != rounds {
+		t.Errorf("last reported value = %d, want %d", last, rounds)
+	}
+}
+
+func TestDiffEntitlements(t *testing.T) {
+	export := lexfloatclient.HostFeatureEntitlement{FeatureName: "export", Value: "yes"}
+	seats10 := lexfloatclient.HostFeatureEntitlement{FeatureName: "seats", Value: "10"}
+	seats20 := lexfloatclient.HostFeatureEntitlement{FeatureName: "seats", Value: "20"}
+	seatsBase := lexfloatclient.HostFeatureEntitlement{FeatureName: "seats", Value: "10", BaseValue: "5"}
+	seatsExpiring := lexfloatclient.HostFeatureEntitlement{FeatureName: "seats", Value: "10", ExpiresAt: 1700000000}
+	seats20Expiring := lexfloatclient.HostFeatureEntitlement{FeatureName: "seats", Value: "20", ExpiresAt: 1700000000}
+	snapshot := func(entitlements ...lexfloatclient.HostFeatureEntitlement) lexfloatclient.EntitlementSnapshot {
+		result := lexfloatclient.EntitlementSnapshot{Entitlements: make(map[string]lexfloatclient.HostFeatureEntitlement)}
+		for _, entitlement := range entitlements {
+			result.Entitlements[entitlement.FeatureName] = entitlement
+		}
+		return result
+	}
+	withMetadata := func(metadata map[string]string, entitlements ...lexfloatclient.HostFeatureEntitlement) lexfloatclient.EntitlementSnapshot {
+		result := snapshot(entitlements...)
+		result.LicenseMetadata = metadata
+		return result
+	}
+	withSet := func(name string, tier int64) lexfloatclient.EntitlementSnapshot {
+		result := snapshot(seats10)
+		result.SetName, result.SetDisplayName, result.SetTier = name, name+" plan", tier
+		return result
+	}
+	tests := []struct {
+		name     string
+		previous lexfloatclient.EntitlementSnapshot
+		current  lexfloatclient.EntitlementSnapshot
+		want     []lexfloatclient.EntitlementChange
+	}{
+		{name: "unchanged", previous: snapshot(seats10, export), current: snapshot(export, seats10)},
+		{name: "empty", previous: lexfloatclient.EntitlementSnapshot{}, current: snapshot()},
+		{name: "added", previous: snapshot(seats10), current: snapshot(seats10, export), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementAdded, Name: "export", New: export},
+		}},
+		{name: "removed", previous: snapshot(seats10, export), current: snapshot(seats10), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementRemoved, Name: "export", Old: export},
+		}},
+		{name: "value changed", previous: snapshot(seats10), current: snapshot(seats20), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementValueChanged, Name: "seats", Old: seats10, New: seats20},
+		}},
+		{name: "base value changed", previous: snapshot(seats10), current: snapshot(seatsBase), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementValueChanged, Name: "seats", Old: seats10, New: seatsBase},
+		}},
+		{name: "expiry changed", previous: snapshot(seats10), current: snapshot(seatsExpiring), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementExpiryChanged, Name: "seats", Old: seats10, New: seatsExpiring},
+		}},
+		{name: "value and expiry changed", previous: snapshot(seats10), current: snapshot(seats20Expiring), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementValueChanged, Name: "seats", Old: seats10, New: seats20Expiring},
+			{Kind: lexfloatclient.EntitlementExpiryChanged, Name: "seats", Old: seats10, New: seats20Expiring},
+		}},
+		{name: "set changed", previous: withSet("basic", 1), current: withSet("pro", 1), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementSetChanged},
+		}},
+		{name: "tier changed", previous: withSet("pro", 1), current: withSet("pro", 2), want: []lexfloatclient.EntitlementChange{
+			{Kind: lexfloatclient.EntitlementSetChanged},
+		}},
+		{
+			name:     "metadata changed",
+			previous: withMetadata(map[string]string{"region": "eu", "plan": "basic", "note": ""}),
+			current:  withMetadata(map[string]string{"region": "us", "owner": "ops"}),
+			want: []lexfloatclient.EntitlementChange{
+				{Kind: lexfloatclient.LicenseMetadataChanged, Name: "note"},
+				{Kind: lexfloatclient.LicenseMetadataChanged, Name: "owner", NewValue: "ops"},
+				{Kind: lexfloatclient.LicenseMetadataChanged, Name: "plan", OldValue: "basic"},
+				{Kind: lexfloatclient.LicenseMetadataChanged, Name: "region", OldValue: "eu", NewValue: "us"},
+			},
+		},
+		{
+			name:     "ordered by kind and name",
+			previous: withMetadata(map[string]string{"region": "eu"}, seats10, export, lexfloatclient.HostFeatureEntitlement{FeatureName: "audit"}),
+			current:  withMetadata(map[string]string{"region": "us"}, seats20, lexfloatclient.HostFeatureEntitlement{FeatureName: "zip"}, lexfloatclient.HostFeatureEntitlement{FeatureName: "backup"}),
+			want: []lexfloatclient.EntitlementChange{
+				{Kind: lexfloatclient.EntitlementAdded, Name: "backup", New: lexfloatclient.HostFeatureEntitlement{FeatureName: "backup"}},
+				{Kind: lexfloatclient.EntitlementAdded, Name: "zip", New: lexfloatclient.HostFeatureEntitlement{FeatureName: "zip"}},
+				{Kind: lexfloatclient.EntitlementRemoved, Name: "audit", Old: lexfloatclient.HostFeatureEntitlement{FeatureName: "audit"}},
+				{Kind: lexfloatclient.EntitlementRemoved, Name: "export", Old: export},
+				{Kind: lexfloatclient.EntitlementValueChanged, Name: "seats", Old: seats10, New: seats20},
+				{Kind: lexfloatclient.LicenseMetadataChanged, Name: "region", OldValue: "eu", NewValue: "us"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := lexfloatclient.DiffEntitlements(test.previous, test.current); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("DiffEntitlements =\n%+v\nwant\n%+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEntitlementChangeKindString(t *testing.T) {
+	if got := lexfloatclient.EntitlementExpiryChanged.String(); got != "EntitlementExpiryChanged" {
+		t.Errorf("String = %q, want EntitlementExpiryChanged", got)
+	}
+	if got := lexfloatclient.EntitlementChangeKind(42).String(); got != "EntitlementChangeKind(42)" {
+		t.Errorf("String = %q, want EntitlementChangeKind(42)", got)
+	}
+}
@@ -15,7 +15,7 @@ func licenseCallback(status int) {
 	} else if status == lexfloatclient.LF_E_LICENSE_EXPIRED_INET {
 		fmt.Println("The license expired due to network connection failure.")
 	} else {
-		fmt.Println("The license renew failed due to other reason. Error code:", status)
+		fmt.Println("The license renew failed due to other reason. Error code:", status, lexfloatclient.Message(status))
 	}
 }
 
@@ -23,18 +23,18 @@ func main() {
 	var status int
 	status = lexfloatclient.SetHostProductId("PASTE_PRODUCT_ID")
 	if lexfloatclient.LF_OK != status {
-		fmt.Println("Error Code:", status)
+		fmt.Println("Error Code:", status, lexfloatclient.Message(status))
 		os.Exit(1)
 	}
 	status = lexfloatclient.SetHostUrl("http://localhost:8090")
 	if lexfloatclient.LF_OK != status {
-		fmt.Println("Error Code:", status)
+		fmt.Println("Error Code:", status, lexfloatclient.Message(status))
 		os.Exit(1)
 	}
 	lexfloatclient.SetFloatingLicenseCallback(licenseCallback)
 	status = lexfloatclient.RequestFloatingLicense()
 	if lexfloatclient.LF_OK != status {
-		fmt.Println("Error Code:", status)
+		fmt.Println("Error Code:", status, lexfloatclient.Message(status))
 		os.Exit(1)
 	}  
 	fmt.Println("Success! License acquired.")
@@ -0,0 +1,21 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "context"
+
+// SetAfterReacquireRequest replaces the hook invoked by LeaseKeeper after each
+// request to re-acquire the lease and returns the previous hook.
+func SetAfterReacquireRequest(hook func(ctx context.Context)) func(ctx context.Context) {
+	previous := afterReacquireRequest
+	afterReacquireRequest = hook
+	return previous
+}
+
+// SetExit replaces the function invoked by HandleShutdown to exit the process
+// and returns the previous function.
+func SetExit(hook func(code int)) func(code int) {
+	previous := exit
+	exit = hook
+	return previous
+}
@@ -8,8 +8,8 @@ package lexfloatclient
 // The gateway functions
 void floatingLicenseCallbackCgoGateway(int status)
 {
-	void floatingLicenseCallbackWrapper(int);
-	floatingLicenseCallbackWrapper(status);
+	void floatingLicenseCallbackCgoWrapper(int);
+	floatingLicenseCallbackCgoWrapper(status);
 }
 */
 import "C"
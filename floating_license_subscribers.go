@@ -0,0 +1,188 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"log"
+	"runtime/debug"
+	"sync"
+)
+
+type floatingLicenseSubscriber struct {
+	listener func(int)
+}
+
+var (
+	floatingLicenseCallbackMutex       sync.RWMutex
+	floatingLicenseCallbackFunction    callbackType
+	floatingLicenseCallbackSubscribers []*floatingLicenseSubscriber
+)
+
+// Subscribe registers a listener that is invoked with the status of every
+// lease renew request, in addition to the function set with
+// SetFloatingLicenseCallback. Any number of listeners can be registered and
+// Subscribe is safe for concurrent use. Listeners are invoked in order of
+// registration on the dispatcher goroutine described at SetFloatingLicenseCallback.
+//
+// The renew callback must still be registered with the library, either with
+// SetFloatingLicenseCallback (which accepts nil) or with NewClient, before
+// RequestFloatingLicense is called.
+//
+// Parameters:
+// - listener: the function that receives the renew status codes
+//
+// Returns: a function that removes the listener. It is safe to call more than once.
+func Subscribe(listener func(status int)) (unsubscribe func()) {
+	subscriber := &floatingLicenseSubscriber{listener: listener}
+	floatingLicenseCallbackMutex.Lock()
+	floatingLicenseCallbackSubscribers = append(floatingLicenseCallbackSubscribers, subscriber)
+	floatingLicenseCallbackMutex.Unlock()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			floatingLicenseCallbackMutex.Lock()
+			defer floatingLicenseCallbackMutex.Unlock()
+			for i, s := range floatingLicenseCallbackSubscribers {
+				if s == subscriber {
+					// Copy so that snapshots taken by the wrapper stay intact.
+					subscribers := make([]*floatingLicenseSubscriber, 0, len(floatingLicenseCallbackSubscribers)-1)
+					subscribers = append(subscribers, floatingLicenseCallbackSubscribers[:i]...)
+					floatingLicenseCallbackSubscribers = append(subscribers, floatingLicenseCallbackSubscribers[i+1:]...)
+					return
+				}
+			}
+		})
+	}
+}
+
+func setFloatingLicenseCallbackFunction(callbackFunction callbackType) {
+	floatingLicenseCallbackMutex.Lock()
+	floatingLicenseCallbackFunction = callbackFunction
+	floatingLicenseCallbackMutex.Unlock()
+}
+
+// installFloatingLicenseCallback registers floatingLicenseCallbackWrapper as
+// the renew callback of the backend.
+func installFloatingLicenseCallback() int {
+	return currentBackend().SetFloatingLicenseCallback(floatingLicenseCallbackWrapper)
+}
+
+// floatingLicenseCallbackWrapper is invoked by the backend on the renew thread
+// of the library. It only queues the status; the callback function and the
+// subscribers are invoked on the dispatcher goroutine, so that they cannot
+// block the renew thread and may call back into the library.
+func floatingLicenseCallbackWrapper(status int) {
+	floatingLicenseDispatcher.push(status)
+}
+
+// SetCallbackPanicHandler sets the function that is invoked when the renew
+// callback function or a subscriber panics. The panic is recovered and the
+// remaining listeners still receive the status.
+//
+// The default handler logs the panic value and stack trace with the log package.
+// Passing nil restores the default handler.
+//
+// Parameters:
+// - handler: the function that receives the recovered value and the stack trace
+func SetCallbackPanicHandler(handler func(value interface{}, stack []byte)) {
+	floatingLicenseCallbackMutex.Lock()
+	callbackPanicHandler = handler
+	floatingLicenseCallbackMutex.Unlock()
+}
+
+// WaitForCallbacks blocks until every renew status received before the call
+// has been delivered to the callback function and the subscribers. It is
+// useful in tests and must not be called from a renew listener.
+func WaitForCallbacks() {
+	floatingLicenseDispatcher.wait()
+}
+
+var callbackPanicHandler func(value interface{}, stack []byte)
+
+func defaultCallbackPanicHandler(value interface{}, stack []byte) {
+	log.Printf("lexfloatclient: renew callback panicked: %v\n%s", value, stack)
+}
+
+// dispatchFloatingLicenseStatus invokes the callback function and all
+// subscribers with the status.
+func dispatchFloatingLicenseStatus(status int) {
+	floatingLicenseCallbackMutex.RLock()
+	callbackFunction := floatingLicenseCallbackFunction
+	subscribers := floatingLicenseCallbackSubscribers
+	panicHandler := callbackPanicHandler
+	floatingLicenseCallbackMutex.RUnlock()
+	if panicHandler == nil {
+		panicHandler = defaultCallbackPanicHandler
+	}
+	if callbackFunction != nil {
+		invokeRenewListener(callbackFunction, status, panicHandler)
+	}
+	for _, subscriber := range subscribers {
+		invokeRenewListener(subscriber.listener, status, panicHandler)
+	}
+}
+
+func invokeRenewListener(listener func(int), status int, panicHandler func(interface{}, []byte)) {
+	defer func() {
+		if value := recover(); value != nil {
+			panicHandler(value, debug.Stack())
+		}
+	}()
+	listener(status)
+}
+
+var floatingLicenseDispatcher = &statusDispatcher{deliver: dispatchFloatingLicenseStatus}
+
+// statusDispatcher delivers statuses in order on a single goroutine that is
+// started on first use.
+type statusDispatcher struct {
+	deliver   func(status int)
+	once      sync.Once
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	statuses  []int
+	queued    uint64
+	delivered uint64
+}
+
+func (dispatcher *statusDispatcher) start() {
+	dispatcher.once.Do(func() {
+		dispatcher.cond = sync.NewCond(&dispatcher.mutex)
+		go dispatcher.run()
+	})
+}
+
+func (dispatcher *statusDispatcher) push(status int) {
+	dispatcher.start()
+	dispatcher.mutex.Lock()
+	dispatcher.statuses = append(dispatcher.statuses, status)
+	dispatcher.queued++
+	dispatcher.mutex.Unlock()
+	dispatcher.cond.Broadcast()
+}
+
+func (dispatcher *statusDispatcher) wait() {
+	dispatcher.start()
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+	target := dispatcher.queued
+	for dispatcher.delivered < target {
+		dispatcher.cond.Wait()
+	}
+}
+
+func (dispatcher *statusDispatcher) run() {
+	dispatcher.mutex.Lock()
+	for {
+		for len(dispatcher.statuses) == 0 {
+			dispatcher.cond.Wait()
+		}
+		status := dispatcher.statuses[0]
+		dispatcher.statuses = dispatcher.statuses[1:]
+		dispatcher.mutex.Unlock()
+		dispatcher.deliver(status)
+		dispatcher.mutex.Lock()
+		dispatcher.delivered++
+		dispatcher.cond.Broadcast()
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// waitForCallbacks fails the test if the renew statuses are not delivered
+// within a second, e.g. because a listener deadlocked.
+func waitForCallbacks(t *testing.T) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		lexfloatclient.WaitForCallbacks()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the renew callbacks")
+	}
+}
+
+func TestSubscribePanic(t *testing.T) {
+	server := leasedServer(t, lexfloattest.Config{})
+	var (
+		mutex  sync.Mutex
+		calls  []string
+		panics []interface{}
+		stacks [][]byte
+	)
+	record := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		calls = append(calls, name)
+	}
+	lexfloatclient.SetCallbackPanicHandler(func(value interface{}, stack []byte) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		panics = append(panics, value)
+		stacks = append(stacks, stack)
+	})
+	t.Cleanup(func() { lexfloatclient.SetCallbackPanicHandler(nil) })
+	lexfloatclient.SetFloatingLicenseCallback(func(int) {
+		record("callback")
+		panic("callback failed")
+	})
+	t.Cleanup(func() { lexfloatclient.SetFloatingLicenseCallback(nil) })
+	defer lexfloatclient.Subscribe(func(int) {
+		record("first")
+		panic("first failed")
+	})()
+	defer lexfloatclient.Subscribe(func(int) { record("second") })()
+	server.Advance(30 * time.Minute)
+	waitForCallbacks(t)
+	mutex.Lock()
+	defer mutex.Unlock()
+	// Each panic is reported and the listeners after it still run, for every
+	// renew status.
+	wantCalls := []string{"callback", "first", "second", "callback", "first", "second"}
+	if !reflect.DeepEqual(calls, wantCalls) {
+		t.Errorf("calls = %v, want %v", calls, wantCalls)
+	}
+	wantPanics := []interface{}{"callback failed", "first failed", "callback failed", "first failed"}
+	if !reflect.DeepEqual(panics, wantPanics) {
+		t.Errorf("panics = %v, want %v", panics, wantPanics)
+	}
+	for i, stack := range stacks {
+		if !bytes.Contains(stack, []byte("TestSubscribePanic")) {
+			t.Errorf("stack %d does not contain the panicking listener:\n%s", i, stack)
+		}
+	}
+}
+
+func TestSubscribeReentrant(t *testing.T) {
+	server := leasedServer(t, lexfloattest.Config{})
+	statuses := make(chan [2]int, 1)
+	unsubscribe := lexfloatclient.Subscribe(func(status int) {
+		if status != lexfloatclient.LF_OK {
+			return
+		}
+		// Lifecycle calls from a listener reach the library without waiting
+		// for the dispatcher.
+		statuses <- [2]int{lexfloatclient.DropFloatingLicense(), lexfloatclient.RequestFloatingLicense()}
+	})
+	defer unsubscribe()
+	server.Advance(15 * time.Minute)
+	waitForCallbacks(t)
+	unsubscribe()
+	select {
+	case got := <-statuses:
+		if got != [2]int{lexfloatclient.LF_OK, lexfloatclient.LF_OK} {
+			t.Errorf("DropFloatingLicense, RequestFloatingLicense = %v, want LF_OK, LF_OK", got)
+		}
+	default:
+		t.Fatal("the listener was not invoked")
+	}
+	if got := server.ActiveLeases(); got != 1 {
+		t.Errorf("ActiveLeases = %d, want 1", got)
+	}
+}
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	server := leasedServer(t, lexfloattest.Config{})
+	var first, second int
+	unsubscribeFirst := lexfloatclient.Subscribe(func(int) { first++ })
+	defer lexfloatclient.Subscribe(func(int) { second++ })()
+	server.Advance(15 * time.Minute)
+	waitForCallbacks(t)
+	unsubscribeFirst()
+	unsubscribeFirst()
+	server.Advance(15 * time.Minute)
+	waitForCallbacks(t)
+	if first != 1 || second != 2 {
+		t.Errorf("first listener called %d times, second %d times, want 1 and 2", first, second)
+	}
+}
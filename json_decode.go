@@ -0,0 +1,98 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// DecodeError is returned when the JSON returned by the library cannot be
+// decoded, e.g. because a library upgrade changed its shape.
+type DecodeError struct {
+	// Function is the name of the library function that returned the JSON.
+	Function string
+
+	// JSON is the raw JSON returned by the library.
+	JSON string
+
+	// Err is the error returned by the JSON decoder.
+	Err error
+}
+
+func (err *DecodeError) Error() string {
+	return fmt.Sprintf("lexfloatclient: %s: decoding JSON: %v", err.Function, err.Err)
+}
+
+func (err *DecodeError) Unwrap() error {
+	return err.Err
+}
+
+var strictDecoding int32
+
+// SetStrictDecoding enables or disables strict decoding of the JSON returned
+// by the library. In strict mode, fields that are unknown to HostConfig and
+// HostFeatureEntitlement are reported as a DecodeError, so that changes of the
+// JSON shape are caught in tests. It is disabled by default.
+//
+// Parameters:
+// - strict: true to reject unknown fields
+//
+// Returns: the previous setting
+func SetStrictDecoding(strict bool) bool {
+	var value int32
+	if strict {
+		value = 1
+	}
+	return atomic.SwapInt32(&strictDecoding, value) == 1
+}
+
+// decodeJSON decodes data into value, honouring SetStrictDecoding.
+func decodeJSON(function string, data string, value interface{}) error {
+	if strings.TrimSpace(data) == "" {
+		return &DecodeError{Function: function, JSON: data, Err: errors.New("empty JSON")}
+	}
+	var err error
+	if atomic.LoadInt32(&strictDecoding) == 1 {
+		decoder := json.NewDecoder(strings.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(value)
+		if err == nil {
+			if _, tokenErr := decoder.Token(); tokenErr != io.EOF {
+				err = errors.New("unexpected data after top-level value")
+			}
+		}
+	} else {
+		err = json.Unmarshal([]byte(data), value)
+	}
+	if err != nil {
+		return &DecodeError{Function: function, JSON: data, Err: err}
+	}
+	return nil
+}
+
+// getJSON gets JSON with get and decodes it into value when get succeeds.
+//
+// Returns: the status returned by get, or LF_FAIL and a *DecodeError if the
+// JSON is empty or cannot be decoded
+func getJSON(function string, get func(json *string) int, value interface{}) (int, error) {
+	var data string
+	status := get(&data)
+	if status != LF_OK {
+		return status, nil
+	}
+	if err := decodeJSON(function, data, value); err != nil {
+		return LF_FAIL, err
+	}
+	return status, nil
+}
+
+func getHostFeatureEntitlement(name string, hostFeatureEntitlement *HostFeatureEntitlement) (int, error) {
+	return getJSON("GetHostFeatureEntitlement", func(json *string) int {
+		return currentBackend().GetHostFeatureEntitlementInternal(name, json)
+	}, hostFeatureEntitlement)
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"testing"
+)
+
+// returnJSON returns a get function for getJSON that returns status and data.
+func returnJSON(status int, data string) func(json *string) int {
+	return func(json *string) int {
+		*json = data
+		return status
+	}
+}
+
+func TestGetJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		strict     bool
+		status     int
+		data       string
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "valid", data: `{"maxOfflineLeaseDuration":60}`, wantStatus: LF_OK},
+		{name: "valid strict", strict: true, data: `{"maxOfflineLeaseDuration":60}`, wantStatus: LF_OK},
+		{name: "unknown field", data: `{"maxOfflineLeaseDuration":60,"region":"eu"}`, wantStatus: LF_OK},
+		{name: "unknown field strict", strict: true, data: `{"maxOfflineLeaseDuration":60,"region":"eu"}`, wantStatus: LF_FAIL, wantErr: true},
+		{name: "trailing data strict", strict: true, data: `{"maxOfflineLeaseDuration":60} {}`, wantStatus: LF_FAIL, wantErr: true},
+		{name: "wrong type", data: `{"maxOfflineLeaseDuration":"60"}`, wantStatus: LF_FAIL, wantErr: true},
+		{name: "truncated", data: `{"maxOfflineLeaseDuration":`, wantStatus: LF_FAIL, wantErr: true},
+		{name: "empty", data: "", wantStatus: LF_FAIL, wantErr: true},
+		{name: "empty strict", strict: true, data: " ", wantStatus: LF_FAIL, wantErr: true},
+		{name: "failed", status: LF_E_INET, wantStatus: LF_E_INET},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer SetStrictDecoding(SetStrictDecoding(test.strict))
+			var hostConfig HostConfig
+			status, err := getJSON("GetHostConfig", returnJSON(test.status, test.data), &hostConfig)
+			if status != test.wantStatus {
+				t.Errorf("status = %d, want %d", status, test.wantStatus)
+			}
+			if !test.wantErr {
+				if err != nil {
+					t.Errorf("err = %v, want nil", err)
+				}
+				if status == LF_OK && hostConfig.MaxOfflineLeaseDuration != 60 {
+					t.Errorf("MaxOfflineLeaseDuration = %d, want 60", hostConfig.MaxOfflineLeaseDuration)
+				}
+				return
+			}
+			var decodeError *DecodeError
+			if !errors.As(err, &decodeError) {
+				t.Fatalf("err = %v, want *DecodeError", err)
+			}
+			if decodeError.Function != "GetHostConfig" || decodeError.JSON != test.data || decodeError.Err == nil {
+				t.Errorf("DecodeError = %+v, want function GetHostConfig, JSON %q and the decoder error", decodeError, test.data)
+			}
+		})
+	}
+}
+
+func TestSetStrictDecoding(t *testing.T) {
+	defer SetStrictDecoding(SetStrictDecoding(false))
+	if previous := SetStrictDecoding(true); previous {
+		t.Error("SetStrictDecoding(true) = true, want false by default")
+	}
+	if previous := SetStrictDecoding(false); !previous {
+		t.Error("SetStrictDecoding(false) = false, want true")
+	}
+}
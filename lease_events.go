@@ -0,0 +1,191 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// LeaseEventKind classifies the outcome of a lease renew request.
+type LeaseEventKind int
+
+const (
+	// LeaseEventRenewed means the lease has been renewed.
+	LeaseEventRenewed LeaseEventKind = iota
+
+	// LeaseEventRenewFailedNetwork means the renew request failed due to a
+	// network or server error. The lease is kept until it expires.
+	LeaseEventRenewFailedNetwork
+
+	// LeaseEventLeaseLost means the lease has expired or no longer exists on
+	// the server (LF_E_LICENSE_EXPIRED_INET, LF_E_LICENSE_NOT_FOUND).
+	LeaseEventLeaseLost
+
+	// LeaseEventServerLicenseProblem means the license of the LexFloatServer
+	// is not activated, suspended or expired, or the server time has been
+	// modified.
+	LeaseEventServerLicenseProblem
+
+	// LeaseEventRenewFailed means the renew request failed for any other
+	// reason.
+	LeaseEventRenewFailed
+)
+
+var leaseEventKindNames = map[LeaseEventKind]string{
+	LeaseEventRenewed:              "Renewed",
+	LeaseEventRenewFailedNetwork:   "RenewFailedNetwork",
+	LeaseEventLeaseLost:            "LeaseLost",
+	LeaseEventServerLicenseProblem: "ServerLicenseProblem",
+	LeaseEventRenewFailed:          "RenewFailed",
+}
+
+// String returns the name of the kind.
+func (kind LeaseEventKind) String() string {
+	if name, ok := leaseEventKindNames[kind]; ok {
+		return name
+	}
+	return "LeaseEventKind(" + strconv.Itoa(int(kind)) + ")"
+}
+
+// leaseEventKindOf derives the kind of a renew status.
+func leaseEventKindOf(status StatusCode) LeaseEventKind {
+	switch {
+	case status == StatusCode(LF_OK):
+		return LeaseEventRenewed
+	case status == ErrLicenseExpiredInet || status == ErrLicenseNotFound:
+		return LeaseEventLeaseLost
+	case status == ErrServerTimeModified || status.Category() == CategoryServerLicense:
+		return LeaseEventServerLicenseProblem
+	case status.Category() == CategoryNetwork:
+		return LeaseEventRenewFailedNetwork
+	default:
+		return LeaseEventRenewFailed
+	}
+}
+
+// LeaseEvent describes the outcome of a lease renew request.
+type LeaseEvent struct {
+	Kind LeaseEventKind
+
+	// Status is the status code passed to the renew callback.
+	Status StatusCode
+
+	// Time is when the renew callback was invoked.
+	Time time.Time
+
+	// LeaseExpiry is the lease expiry date after the renew request. It is
+	// the zero time when the floating client holds no lease.
+	LeaseExpiry time.Time
+
+	// Mode is the mode of the floating license. It is empty when the
+	// floating client holds no lease.
+	Mode LeaseMode
+}
+
+// Err returns nil for a renewed lease, otherwise the status code.
+func (event LeaseEvent) Err() error {
+	return StatusError(int(event.Status))
+}
+
+const leaseEventsBufferSize = 16
+
+// Events returns a channel that receives a LeaseEvent for every lease renew
+// request. The channel is never closed and its subscription is never removed,
+// so Events should be called at most once per process; use EventsContext for
+// streams that must stop.
+//
+// Like Subscribe, it requires the renew callback to be registered with
+// SetFloatingLicenseCallback or NewClient.
+func Events() <-chan LeaseEvent {
+	return EventsContext(context.Background())
+}
+
+// EventsContext returns a channel that receives a LeaseEvent for every lease
+// renew request until ctx is done, after which the channel is closed.
+//
+// The lease expiry and mode of each event are read when the renew status is
+// received, so they describe the lease right after that renew request. Events
+// are then queued without limit and delivered on their own goroutine, so a
+// slow consumer never blocks the renew thread of the library.
+func EventsContext(ctx context.Context) <-chan LeaseEvent {
+	events := make(chan LeaseEvent, leaseEventsBufferSize)
+	queue := newLeaseEventQueue()
+	unsubscribe := Subscribe(func(status int) {
+		queue.push(newLeaseEvent(StatusCode(status), currentTime()))
+	})
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+		for {
+			event, ok := queue.pop(ctx)
+			if !ok {
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+func newLeaseEvent(status StatusCode, now time.Time) LeaseEvent {
+	event := LeaseEvent{
+		Kind:   leaseEventKindOf(status),
+		Status: status,
+		Time:   now,
+	}
+	if leaseExpiry, err := FloatingClientLeaseExpiryTime(); err == nil {
+		event.LeaseExpiry = leaseExpiry
+	}
+	if mode, err := FloatingLicenseMode(); err == nil {
+		event.Mode = mode
+	}
+	return event
+}
+
+// leaseEventQueue is an unbounded FIFO queue of lease events.
+type leaseEventQueue struct {
+	mutex  sync.Mutex
+	events []LeaseEvent
+	notify chan struct{}
+}
+
+func newLeaseEventQueue() *leaseEventQueue {
+	return &leaseEventQueue{notify: make(chan struct{}, 1)}
+}
+
+func (queue *leaseEventQueue) push(event LeaseEvent) {
+	queue.mutex.Lock()
+	queue.events = append(queue.events, event)
+	queue.mutex.Unlock()
+	select {
+	case queue.notify <- struct{}{}:
+	default:
+	}
+}
+
+// pop blocks until an event is queued or ctx is done.
+func (queue *leaseEventQueue) pop(ctx context.Context) (LeaseEvent, bool) {
+	for {
+		queue.mutex.Lock()
+		if len(queue.events) > 0 {
+			event := queue.events[0]
+			queue.events[0] = LeaseEvent{}
+			queue.events = queue.events[1:]
+			queue.mutex.Unlock()
+			return event, true
+		}
+		queue.mutex.Unlock()
+		select {
+		case <-queue.notify:
+		case <-ctx.Done():
+			return LeaseEvent{}, false
+		}
+	}
+}
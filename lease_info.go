@@ -0,0 +1,70 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"time"
+)
+
+// LeaseMode is the mode of a floating license lease.
+type LeaseMode string
+
+const (
+	// LeaseModeNone is the mode reported by CurrentLeaseInfo when the
+	// floating client holds no lease.
+	LeaseModeNone LeaseMode = ""
+
+	// LeaseModeOnline is a lease that is renewed periodically while the
+	// LexFloatServer is reachable.
+	LeaseModeOnline LeaseMode = "online"
+
+	// LeaseModeOffline is a lease requested with RequestOfflineFloatingLicense
+	// that is valid until it expires without contacting the LexFloatServer.
+	LeaseModeOffline LeaseMode = "offline"
+)
+
+// LeaseInfo describes the lease held by the floating client.
+type LeaseInfo struct {
+	// Leased reports whether the floating client holds a valid lease. The
+	// other fields are zero when it does not.
+	Leased bool
+
+	// Mode is the mode of the lease, or LeaseModeNone without a lease.
+	Mode LeaseMode
+
+	// Expiry is the lease expiry date.
+	Expiry time.Time
+
+	// OfflineRemaining is the time left until an offline lease expires. It is
+	// zero for online leases.
+	OfflineRemaining time.Duration
+}
+
+// CurrentLeaseInfo returns the lease held by the floating client.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_BUFFER_SIZE or the other statuses of HasFloatingLicense
+// except LF_E_NO_LICENSE, which is reported as a LeaseInfo with LeaseModeNone
+func CurrentLeaseInfo() (LeaseInfo, error) {
+	if err := StatusError(HasFloatingLicense()); err != nil {
+		if errors.Is(err, ErrNoLicense) {
+			return LeaseInfo{Mode: LeaseModeNone}, nil
+		}
+		return LeaseInfo{}, err
+	}
+	mode, err := FloatingLicenseMode()
+	if err != nil {
+		return LeaseInfo{}, err
+	}
+	expiry, err := FloatingClientLeaseExpiryTime()
+	if err != nil {
+		return LeaseInfo{}, err
+	}
+	leaseInfo := LeaseInfo{Leased: true, Mode: mode, Expiry: expiry}
+	if mode == LeaseModeOffline && !expiry.IsZero() {
+		if remaining := timeUntil(expiry); remaining > 0 {
+			leaseInfo.OfflineRemaining = remaining
+		}
+	}
+	return leaseInfo, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestCurrentLeaseInfo(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	server := lexfloattest.NewServer(lexfloattest.Config{Start: start, MaxOfflineLeaseDuration: 24 * time.Hour})
+	useBackend(t, server, server.NewBackend())
+	leaseInfo, err := lexfloatclient.CurrentLeaseInfo()
+	if err != nil || leaseInfo != (lexfloatclient.LeaseInfo{Mode: lexfloatclient.LeaseModeNone}) {
+		t.Errorf("without a lease: CurrentLeaseInfo = %+v, %v, want no lease with LeaseModeNone", leaseInfo, err)
+	}
+	if err := lexfloatclient.RequestFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("RequestFloatingLicenseContext: %v", err)
+	}
+	leaseInfo, err = lexfloatclient.CurrentLeaseInfo()
+	want := lexfloatclient.LeaseInfo{Leased: true, Mode: lexfloatclient.LeaseModeOnline, Expiry: start.Add(lexfloattest.DefaultLeaseDuration)}
+	if err != nil || leaseInfo != want {
+		t.Errorf("online: CurrentLeaseInfo = %+v, %v, want %+v", leaseInfo, err, want)
+	}
+	if err := lexfloatclient.DropFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("DropFloatingLicenseContext: %v", err)
+	}
+	leaseInfo, err = lexfloatclient.CurrentLeaseInfo()
+	if err != nil || leaseInfo.Leased || leaseInfo.Mode != lexfloatclient.LeaseModeNone {
+		t.Errorf("after drop: CurrentLeaseInfo = %+v, %v, want no lease with LeaseModeNone", leaseInfo, err)
+	}
+}
+
+func TestCurrentLeaseInfoOffline(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	server := lexfloattest.NewServer(lexfloattest.Config{Start: start, MaxOfflineLeaseDuration: 24 * time.Hour})
+	useBackend(t, server, server.NewBackend())
+	if err := lexfloatclient.RequestOfflineFloatingLicenseFor(2 * time.Hour); err != nil {
+		t.Fatalf("RequestOfflineFloatingLicenseFor: %v", err)
+	}
+	expiry := start.Add(2 * time.Hour)
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    time.Duration
+	}{
+		{name: "requested", elapsed: 0, want: 2 * time.Hour},
+		{name: "half used", elapsed: time.Hour, want: time.Hour},
+		{name: "almost expired", elapsed: 2*time.Hour - time.Second, want: time.Second},
+		// The local clock may be ahead of the library, which still reports
+		// the lease; the remaining time does not become negative.
+		{name: "expired", elapsed: 3 * time.Hour, want: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer lexfloatclient.SetClock(lexfloatclient.SetClock(func() time.Time { return start.Add(test.elapsed) }))
+			leaseInfo, err := lexfloatclient.CurrentLeaseInfo()
+			want := lexfloatclient.LeaseInfo{Leased: true, Mode: lexfloatclient.LeaseModeOffline, Expiry: expiry, OfflineRemaining: test.want}
+			if err != nil || leaseInfo != want {
+				t.Errorf("CurrentLeaseInfo = %+v, %v, want %+v", leaseInfo, err, want)
+			}
+		})
+	}
+	// Once the offline lease has expired, the floating client holds no lease.
+	server.Advance(2 * time.Hour)
+	if leaseInfo, err := lexfloatclient.CurrentLeaseInfo(); err != nil || leaseInfo.Leased {
+		t.Errorf("after expiry: CurrentLeaseInfo = %+v, %v, want no lease", leaseInfo, err)
+	}
+}
+
+func TestCurrentLeaseInfoError(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	// A client without a product id cannot report its lease.
+	defer server.Install(server.NewBackend())()
+	if leaseInfo, err := lexfloatclient.CurrentLeaseInfo(); !errors.Is(err, lexfloatclient.ErrProductId) || leaseInfo.Leased {
+		t.Errorf("CurrentLeaseInfo = %+v, %v, want ErrProductId", leaseInfo, err)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// LeaseState is the state of the lease owned by a LeaseKeeper.
+type LeaseState int
+
+const (
+	// LeaseStateIdle means the keeper has not been started or has been stopped.
+	LeaseStateIdle LeaseState = iota
+
+	// LeaseStateLeased means the floating client holds a lease.
+	LeaseStateLeased
+
+	// LeaseStateReacquiring means the lease has been lost and the keeper is
+	// requesting a new one.
+	LeaseStateReacquiring
+
+	// LeaseStateLost means the lease has been lost and the keeper has given up
+	// re-acquiring it.
+	LeaseStateLost
+)
+
+var leaseStateNames = map[LeaseState]string{
+	LeaseStateIdle:        "Idle",
+	LeaseStateLeased:      "Leased",
+	LeaseStateReacquiring: "Reacquiring",
+	LeaseStateLost:        "Lost",
+}
+
+// String returns the name of the state.
+func (state LeaseState) String() string {
+	if name, ok := leaseStateNames[state]; ok {
+		return name
+	}
+	return "LeaseState(" + strconv.Itoa(int(state)) + ")"
+}
+
+// LeaseKeeperOptions configures a LeaseKeeper.
+type LeaseKeeperOptions struct {
+	// Backoff configures the delay between attempts to re-acquire the lease.
+	Backoff Backoff
+
+	// MaxAttempts limits the attempts to re-acquire the lease before the
+	// keeper gives up. Zero means unlimited.
+	MaxAttempts int
+
+	// OnStateChange is invoked on every state transition with the error that
+	// caused it, if any. It may be nil.
+	OnStateChange func(from LeaseState, to LeaseState, err error)
+}
+
+// LeaseKeeper owns the lease of the floating client. When a renew reports
+// LF_E_LICENSE_EXPIRED_INET or LF_E_LICENSE_NOT_FOUND, it requests a new lease
+// with jittered exponential backoff until it succeeds, a non-retryable error
+// occurs or MaxAttempts is reached.
+//
+// The library must be configured, e.g. with NewClient, before the keeper is
+// started.
+type LeaseKeeper struct {
+	options LeaseKeeperOptions
+
+	mutex       sync.Mutex
+	state       LeaseState
+	started     bool
+	unsubscribe func()
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewLeaseKeeper returns a LeaseKeeper in the LeaseStateIdle state.
+func NewLeaseKeeper(options LeaseKeeperOptions) *LeaseKeeper {
+	return &LeaseKeeper{options: options}
+}
+
+// State returns the current state of the lease.
+func (keeper *LeaseKeeper) State() LeaseState {
+	keeper.mutex.Lock()
+	defer keeper.mutex.Unlock()
+	return keeper.state
+}
+
+// Start requests the lease and starts watching the renew statuses.
+//
+// Errors: ctx.Err(), an error if the keeper is already started, or see RequestFloatingLicense
+func (keeper *LeaseKeeper) Start(ctx context.Context) error {
+	keeper.mutex.Lock()
+	if keeper.started {
+		keeper.mutex.Unlock()
+		return errors.New("lexfloatclient: lease keeper already started")
+	}
+	keeper.started = true
+	keeper.mutex.Unlock()
+	if err := RequestFloatingLicenseContext(ctx); err != nil {
+		keeper.mutex.Lock()
+		keeper.started = false
+		keeper.mutex.Unlock()
+		return opError("RequestFloatingLicense", err)
+	}
+	keeper.mutex.Lock()
+	keeper.unsubscribe = Subscribe(keeper.renewed)
+	keeper.mutex.Unlock()
+	keeper.transition(LeaseStateLeased, nil)
+	return nil
+}
+
+// Stop stops re-acquiring the lease and drops the lease if it is held, also
+// when a re-acquired lease was granted while the keeper was being stopped.
+// The keeper returns to LeaseStateIdle and can be started again.
+//
+// Errors: ctx.Err() or see DropFloatingLicense
+func (keeper *LeaseKeeper) Stop(ctx context.Context) error {
+	keeper.mutex.Lock()
+	if keeper.unsubscribe == nil {
+		keeper.mutex.Unlock()
+		return nil
+	}
+	keeper.unsubscribe()
+	keeper.unsubscribe = nil
+	cancel, done := keeper.cancel, keeper.done
+	keeper.cancel, keeper.done = nil, nil
+	keeper.mutex.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	var err error
+	if HasFloatingLicense() == LF_OK {
+		err = opError("DropFloatingLicense", DropFloatingLicenseContext(ctx))
+	}
+	keeper.transition(LeaseStateIdle, err)
+	keeper.mutex.Lock()
+	keeper.started = false
+	keeper.mutex.Unlock()
+	return err
+}
+
+// renewed is subscribed to the renew statuses.
+func (keeper *LeaseKeeper) renewed(status int) {
+	if status != LF_E_LICENSE_EXPIRED_INET && status != LF_E_LICENSE_NOT_FOUND {
+		return
+	}
+	keeper.mutex.Lock()
+	if keeper.state != LeaseStateLeased || keeper.unsubscribe == nil {
+		keeper.mutex.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	keeper.cancel = cancel
+	keeper.done = make(chan struct{})
+	done := keeper.done
+	keeper.mutex.Unlock()
+	keeper.transition(LeaseStateReacquiring, StatusCode(status))
+	go keeper.reacquire(ctx, done)
+}
+
+// afterReacquireRequest is invoked after each request to re-acquire the lease.
+// It is replaced in tests to stop the keeper at that point.
+var afterReacquireRequest = func(ctx context.Context) {}
+
+func (keeper *LeaseKeeper) reacquire(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	for attempt := 0; keeper.options.MaxAttempts <= 0 || attempt < keeper.options.MaxAttempts; attempt++ {
+		if sleepContext(ctx, keeper.options.Backoff.Delay(attempt)) != nil {
+			return
+		}
+		err := RequestFloatingLicenseContext(ctx)
+		afterReacquireRequest(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil || errors.Is(err, ErrLicenseExists) {
+			keeper.finishReacquire(ctx, LeaseStateLeased, nil)
+			return
+		}
+		if !IsRetryable(err) {
+			keeper.finishReacquire(ctx, LeaseStateLost, err)
+			return
+		}
+	}
+	keeper.finishReacquire(ctx, LeaseStateLost, errors.New("lexfloatclient: lease keeper gave up re-acquiring the lease"))
+}
+
+// finishReacquire transitions out of LeaseStateReacquiring unless the keeper
+// is being stopped.
+func (keeper *LeaseKeeper) finishReacquire(ctx context.Context, to LeaseState, err error) {
+	keeper.mutex.Lock()
+	if ctx.Err() != nil {
+		keeper.mutex.Unlock()
+		return
+	}
+	keeper.cancel, keeper.done = nil, nil
+	keeper.mutex.Unlock()
+	keeper.transition(to, err)
+}
+
+func (keeper *LeaseKeeper) transition(to LeaseState, err error) {
+	keeper.mutex.Lock()
+	from := keeper.state
+	keeper.state = to
+	keeper.mutex.Unlock()
+	if from != to && keeper.options.OnStateChange != nil {
+		keeper.options.OnStateChange(from, to, err)
+	}
+}
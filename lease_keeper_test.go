@@ -0,0 +1,233 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// stateRecorder records the transitions of a LeaseKeeper.
+type stateRecorder struct {
+	mutex  sync.Mutex
+	states []lexfloatclient.LeaseState
+	errs   []error
+}
+
+func (recorder *stateRecorder) record(from lexfloatclient.LeaseState, to lexfloatclient.LeaseState, err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.states = append(recorder.states, to)
+	recorder.errs = append(recorder.errs, err)
+}
+
+func (recorder *stateRecorder) get() ([]lexfloatclient.LeaseState, []error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]lexfloatclient.LeaseState(nil), recorder.states...), append([]error(nil), recorder.errs...)
+}
+
+// useBackend installs backend, a backend of server, and the clock of server
+// for the duration of the test and configures the backend with NewClient.
+func useBackend(t *testing.T, server *lexfloattest.Server, backend lexfloatclient.Backend) {
+	t.Helper()
+	t.Cleanup(server.Install(backend))
+	if _, err := lexfloatclient.NewClient(lexfloatclient.Options{
+		ProductID: "product",
+		HostURL:   "http://localhost:8090",
+	}); err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+}
+
+func newLeaseKeeper(recorder *stateRecorder) *lexfloatclient.LeaseKeeper {
+	return lexfloatclient.NewLeaseKeeper(lexfloatclient.LeaseKeeperOptions{
+		Backoff: lexfloatclient.Backoff{
+			Initial: time.Millisecond,
+			Max:     5 * time.Millisecond,
+		},
+		OnStateChange: recorder.record,
+	})
+}
+
+// waitFor polls condition until it holds or a second has passed.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// revoke revokes the lease and advances the virtual clock to the next renew,
+// which reports LF_E_LICENSE_NOT_FOUND.
+func revoke(server *lexfloattest.Server) {
+	server.RevokeLeases()
+	server.Advance(15 * time.Minute)
+	lexfloatclient.WaitForCallbacks()
+}
+
+func TestLeaseKeeperReacquires(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	recorder := &stateRecorder{}
+	keeper := newLeaseKeeper(recorder)
+	if err := keeper.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	revoke(server)
+	waitFor(t, "LeaseStateLeased", func() bool {
+		return keeper.State() == lexfloatclient.LeaseStateLeased && server.Calls(lexfloattest.OpRequestFloatingLicense) == 2
+	})
+	states, errs := recorder.get()
+	want := []lexfloatclient.LeaseState{lexfloatclient.LeaseStateLeased, lexfloatclient.LeaseStateReacquiring, lexfloatclient.LeaseStateLeased}
+	if !reflect.DeepEqual(states, want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+	if !errors.Is(errs[1], lexfloatclient.ErrLicenseNotFound) {
+		t.Errorf("Reacquiring error = %v, want ErrLicenseNotFound", errs[1])
+	}
+	if got := server.ActiveLeases(); got != 1 {
+		t.Errorf("ActiveLeases = %d, want 1", got)
+	}
+	if err := keeper.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if got := server.ActiveLeases(); got != 0 {
+		t.Errorf("after Stop: ActiveLeases = %d, want 0", got)
+	}
+}
+
+func TestLeaseKeeperLost(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	server.AddFault(lexfloattest.Fault{
+		Op:     lexfloattest.OpRequestFloatingLicense,
+		Status: lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED,
+		Call:   2,
+	})
+	recorder := &stateRecorder{}
+	keeper := newLeaseKeeper(recorder)
+	if err := keeper.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	revoke(server)
+	waitFor(t, "LeaseStateLost", func() bool {
+		return keeper.State() == lexfloatclient.LeaseStateLost
+	})
+	states, errs := recorder.get()
+	want := []lexfloatclient.LeaseState{lexfloatclient.LeaseStateLeased, lexfloatclient.LeaseStateReacquiring, lexfloatclient.LeaseStateLost}
+	if !reflect.DeepEqual(states, want) {
+		t.Fatalf("states = %v, want %v", states, want)
+	}
+	if !errors.Is(errs[2], lexfloatclient.ErrServerLicenseSuspended) {
+		t.Errorf("Lost error = %v, want ErrServerLicenseSuspended", errs[2])
+	}
+	if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != 2 {
+		t.Errorf("RequestFloatingLicense calls = %d, want 2", got)
+	}
+	if err := keeper.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+}
+
+// blockingBackend blocks RequestFloatingLicense once armed until it is
+// released.
+type blockingBackend struct {
+	*lexfloattest.Backend
+	armed    chan struct{}
+	blocked  chan struct{}
+	released chan struct{}
+}
+
+func (backend *blockingBackend) RequestFloatingLicense() int {
+	select {
+	case <-backend.armed:
+		close(backend.blocked)
+		<-backend.released
+	default:
+	}
+	return backend.Backend.RequestFloatingLicense()
+}
+
+func TestLeaseKeeperStopWhileReacquiring(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	backend := &blockingBackend{
+		Backend:  server.NewBackend(),
+		armed:    make(chan struct{}),
+		blocked:  make(chan struct{}),
+		released: make(chan struct{}),
+	}
+	useBackend(t, server, backend)
+	recorder := &stateRecorder{}
+	keeper := newLeaseKeeper(recorder)
+	if err := keeper.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	close(backend.armed)
+	revoke(server)
+	<-backend.blocked
+	if got := keeper.State(); got != lexfloatclient.LeaseStateReacquiring {
+		t.Fatalf("State = %v, want LeaseStateReacquiring", got)
+	}
+	if err := keeper.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if got := keeper.State(); got != lexfloatclient.LeaseStateIdle {
+		t.Errorf("State = %v, want LeaseStateIdle", got)
+	}
+	// The request was in flight when the keeper stopped; the lease it grants
+	// must be dropped.
+	close(backend.released)
+	waitFor(t, "the late lease to be dropped", func() bool {
+		return server.Calls(lexfloattest.OpDropFloatingLicense) == 1 && server.ActiveLeases() == 0
+	})
+	states, _ := recorder.get()
+	want := []lexfloatclient.LeaseState{lexfloatclient.LeaseStateLeased, lexfloatclient.LeaseStateReacquiring, lexfloatclient.LeaseStateIdle}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
+
+// TestLeaseKeeperStopAfterGrant stops the keeper after a re-acquired lease has
+// been granted, but before the keeper has checked whether it is being stopped.
+func TestLeaseKeeperStopAfterGrant(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	granted := make(chan struct{})
+	defer lexfloatclient.SetAfterReacquireRequest(lexfloatclient.SetAfterReacquireRequest(func(ctx context.Context) {
+		close(granted)
+		<-ctx.Done()
+	}))
+	recorder := &stateRecorder{}
+	keeper := newLeaseKeeper(recorder)
+	if err := keeper.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	revoke(server)
+	<-granted
+	if got := server.ActiveLeases(); got != 1 {
+		t.Fatalf("ActiveLeases = %d, want 1", got)
+	}
+	if err := keeper.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if got := server.ActiveLeases(); got != 0 {
+		t.Errorf("after Stop: ActiveLeases = %d, want 0", got)
+	}
+	states, _ := recorder.get()
+	want := []lexfloatclient.LeaseState{lexfloatclient.LeaseStateLeased, lexfloatclient.LeaseStateReacquiring, lexfloatclient.LeaseStateIdle}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
@@ -2,23 +2,6 @@
 
 package lexfloatclient
 
-/*
-#cgo linux,!arm64 LDFLAGS: -Wl,-Bstatic -L${SRCDIR}/libs/linux_amd64 -lLexFloatClient -Wl,-Bdynamic -lm -lstdc++ -lpthread
-#cgo linux,arm64 LDFLAGS: -Wl,-Bstatic -L${SRCDIR}/libs/linux_arm64 -lLexFloatClient -Wl,-Bdynamic -lm -lstdc++ -lpthread
-#cgo darwin LDFLAGS: -L${SRCDIR}/libs/darwin_universal -lLexFloatClient -lc++ -framework CoreFoundation -framework SystemConfiguration -framework Security
-#cgo windows LDFLAGS: -L${SRCDIR}/libs/windows_amd64 -lLexFloatClient
-#include "lexfloatclient/LexFloatClient.h"
-#include <stdlib.h>
-void floatingLicenseCallbackCgoGateway(int status);
-*/
-import "C"
-import (
-	"encoding/json"
-	"strings"
-	"unsafe"
-)
-
-
 type HostConfig struct {
 	MaxOfflineLeaseDuration int `json:"maxOfflineLeaseDuration"`
 }
@@ -38,15 +21,6 @@ const (
     LF_ALL_USERS uint = 11
 )
 
-var floatingLicenseCallbackFunction callbackType
-
-//export floatingLicenseCallbackWrapper
-func floatingLicenseCallbackWrapper(status int) {
-	if floatingLicenseCallbackFunction != nil {
-		floatingLicenseCallbackFunction(status)
-	}
-}
-
 // SetPermissionFlag sets the permission flag.
 //
 // This function must be called on every start of your program after SetHostProductId()
@@ -60,9 +34,7 @@ func floatingLicenseCallbackWrapper(status int) {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID
 func SetPermissionFlag(flags uint) int {
-	cFlags := (C.uint)(flags)
-	status := C.SetPermissionFlag(cFlags)
-	return int(status)
+	return currentBackend().SetPermissionFlag(flags)
 }
 
 // SetHostProductId sets the product id of your application.
@@ -73,10 +45,7 @@ func SetPermissionFlag(flags uint) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID
 func SetHostProductId(productId string) int {
-	cProductId := goToCString(productId)
-	status := C.SetHostProductId(cProductId)
-	freeCString(cProductId)
-	return int(status)
+	return currentBackend().SetHostProductId(productId)
 }
 
 // SetHostUrl sets the network address of the LexFloatServer.
@@ -88,10 +57,7 @@ func SetHostProductId(productId string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_HOST_URL
 func SetHostUrl(hostUrl string) int {
-	cHostUrl := goToCString(hostUrl)
-	status := C.SetHostUrl(cHostUrl)
-	freeCString(cHostUrl)
-	return int(status)
+	return currentBackend().SetHostUrl(hostUrl)
 }
 
 // SetFloatingLicenseCallback sets the renew license callback function.
@@ -103,12 +69,20 @@ func SetHostUrl(hostUrl string) int {
 // LF_OK, LF_E_INET, LF_E_LICENSE_EXPIRED_INET, LF_E_LICENSE_NOT_FOUND, LF_E_CLIENT, LF_E_IP,
 // LF_E_SERVER, LF_E_TIME, LF_E_SERVER_LICENSE_NOT_ACTIVATED,LF_E_SERVER_TIME_MODIFIED,
 // LF_E_SERVER_LICENSE_SUSPENDED, LF_E_SERVER_LICENSE_EXPIRED, LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER
-// 
+//
+// The callback function replaces the one set by a previous call. It may be nil
+// when the renew statuses are only consumed by listeners registered with
+// Subscribe.
+//
+// The callback function is invoked on a dispatcher goroutine rather than on the
+// renew thread of the library, so it may call other functions of this package,
+// including DropFloatingLicense and RequestFloatingLicense. Panics are recovered
+// and reported to the handler set with SetCallbackPanicHandler.
+//
 // Returns: LF_OK, LF_E_PRODUCT_ID
 func SetFloatingLicenseCallback(callbackFunction func(int)) int {
-	status := C.SetFloatingLicenseCallback((C.CallbackType)(unsafe.Pointer(C.floatingLicenseCallbackCgoGateway)))
-	floatingLicenseCallbackFunction = callbackFunction
-	return int(status)
+	setFloatingLicenseCallbackFunction(callbackFunction)
+	return installFloatingLicenseCallback()
 }
 
 // SetFloatingClientMetadata sets the floating client metadata.
@@ -123,12 +97,7 @@ func SetFloatingLicenseCallback(callbackFunction func(int)) int {
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_METADATA_KEY_LENGTH,
 // LF_E_METADATA_VALUE_LENGTH, LF_E_ACTIVATION_METADATA_LIMIT
 func SetFloatingClientMetadata(key string, value string) int {
-	cKey := goToCString(key)
-	cValue := goToCString(value)
-	status := C.SetFloatingClientMetadata(cKey, cValue)
-	freeCString(cKey)
-	freeCString(cValue)
-	return int(status)
+	return currentBackend().SetFloatingClientMetadata(key, value)
 }
 
 // GetFloatingClientLibraryVersion gets the version of this library.
@@ -138,10 +107,7 @@ func SetFloatingClientMetadata(key string, value string) int {
 //
 // Returns: LF_OK, LF_E_BUFFER_SIZE
 func GetFloatingClientLibraryVersion(libraryVersion *string) int {
-	var cLibraryVersion = getCArray()
-	status := C.GetFloatingClientLibraryVersion(&cLibraryVersion[0], maxCArrayLength)
-	*libraryVersion = ctoGoString(&cLibraryVersion[0])
-	return int(status)
+	return currentBackend().GetFloatingClientLibraryVersion(libraryVersion)
 }
 
 // GetHostProductVersionName gets the product version name.
@@ -153,10 +119,7 @@ func GetFloatingClientLibraryVersion(libraryVersion *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_BUFFER_SIZE
 func GetHostProductVersionName(name *string) int {
-	var cName = getCArray()
-	status := C.GetHostProductVersionName(&cName[0], maxCArrayLength)
-	*name = ctoGoString(&cName[0])
-	return int(status)
+	return currentBackend().GetHostProductVersionName(name)
 }
 
 // GetHostProductVersionDisplayName gets the product version display name.
@@ -168,10 +131,7 @@ func GetHostProductVersionName(name *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_BUFFER_SIZE
 func GetHostProductVersionDisplayName(displayName *string) int {
-	var cDisplayName = getCArray()
-	status := C.GetHostProductVersionDisplayName(&cDisplayName[0], maxCArrayLength)
-	*displayName = ctoGoString(&cDisplayName[0])
-	return int(status)
+	return currentBackend().GetHostProductVersionDisplayName(displayName)
 }
 
 // GetHostProductVersionFeatureFlag gets the product version feature flag.
@@ -185,14 +145,7 @@ func GetHostProductVersionDisplayName(displayName *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_FEATURE_FLAG_NOT_FOUND, LF_E_BUFFER_SIZE
 func GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string) int {
-    cName := goToCString(name)
-    var cEnabled C.uint
-    var cData = getCArray()
-    status := C.GetHostProductVersionFeatureFlag(cName, &cEnabled, &cData[0], maxCArrayLength)
-    freeCString(cName)
-    *enabled = cEnabled > 0
-    *data = ctoGoString(&cData[0])
-    return int(status)
+	return currentBackend().GetHostProductVersionFeatureFlag(name, enabled, data)
 }
 
 // GetHostLicenseEntitlementSetName gets the name of the entitlement set associated with the LexFloatServer license.
@@ -202,10 +155,7 @@ func GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string)
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_ENTITLEMENT_SET_NOT_LINKED
 func GetHostLicenseEntitlementSetName(name *string) int {
-    var cName = getCArray()
-    status := C.GetHostLicenseEntitlementSetName(&cName[0], maxCArrayLength)
-    *name = ctoGoString(&cName[0])
-    return int(status)
+	return currentBackend().GetHostLicenseEntitlementSetName(name)
 }
 
 // GetHostLicenseEntitlementSetDisplayName gets the display name of the entitlement set associated with the LexFloatServer license.
@@ -215,10 +165,7 @@ func GetHostLicenseEntitlementSetName(name *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_ENTITLEMENT_SET_NOT_LINKED
 func GetHostLicenseEntitlementSetDisplayName(displayName *string) int {
-    var cDisplayName = getCArray()
-    status := C.GetHostLicenseEntitlementSetDisplayName(&cDisplayName[0], maxCArrayLength)
-    *displayName = ctoGoString(&cDisplayName[0])
-    return int(status)
+	return currentBackend().GetHostLicenseEntitlementSetDisplayName(displayName)
 }
 
 // GetHostLicenseEntitlementSetTier gets the tier of the entitlement set associated with the LexFloatServer license.
@@ -228,10 +175,7 @@ func GetHostLicenseEntitlementSetDisplayName(displayName *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_ENTITLEMENT_SET_NOT_LINKED
 func GetHostLicenseEntitlementSetTier(tier *int64) int {
-    var cTier C.int64_t
-    status := C.GetHostLicenseEntitlementSetTier(&cTier)
-    *tier = int64(cTier)
-    return int(status)
+	return currentBackend().GetHostLicenseEntitlementSetTier(tier)
 }
 
 // GetHostFeatureEntitlements gets the feature entitlements associated with the LexFloatServer license.
@@ -239,16 +183,11 @@ func GetHostLicenseEntitlementSetTier(tier *int64) int {
 // Parameters:
 // - hostFeatureEntitlements: pointer to an array of HostFeatureEntitlement structs that receives the value
 //
-// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE
+// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_FAIL if the JSON
+// returned by the library cannot be decoded
 func GetHostFeatureEntitlements(hostFeatureEntitlements *[]HostFeatureEntitlement) int {
-    var cHostFeatureEntitlements = getCArray()
-    hostFeatureEntitlementsJson := ""
-    status := C.GetHostFeatureEntitlementsInternal(&cHostFeatureEntitlements[0], maxCArrayLength)
-    hostFeatureEntitlementsJson = strings.TrimRight(ctoGoString(&cHostFeatureEntitlements[0]), "\x00")
-    if hostFeatureEntitlementsJson != "" {
-        json.Unmarshal([]byte(hostFeatureEntitlementsJson), hostFeatureEntitlements)
-    }
-    return int(status)
+	status, _ := getJSON("GetHostFeatureEntitlements", currentBackend().GetHostFeatureEntitlementsInternal, hostFeatureEntitlements)
+	return status
 }
 
 // GetHostFeatureEntitlement gets the value of the feature entitlement field associated with the LexFloatServer license.
@@ -257,17 +196,11 @@ func GetHostFeatureEntitlements(hostFeatureEntitlements *[]HostFeatureEntitlemen
 // - name: name of the feature
 // - hostFeatureEntitlement: pointer to the HostFeatureEntitlement struct that receives the value
 //
-// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_FEATURE_ENTITLEMENT_NOT_FOUND
+// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_FEATURE_ENTITLEMENT_NOT_FOUND,
+// LF_FAIL if the JSON returned by the library cannot be decoded
 func GetHostFeatureEntitlement(name string, hostFeatureEntitlement *HostFeatureEntitlement) int {
-	cName := goToCString(name)
-	var cHostFeatureEntitlement = getCArray()
-	status := C.GetHostFeatureEntitlementInternal(cName, &cHostFeatureEntitlement[0], maxCArrayLength)
-	freeCString(cName)
-	hostFeatureEntitlementJson := strings.TrimRight(ctoGoString(&cHostFeatureEntitlement[0]), "\x00")
-	if hostFeatureEntitlementJson != "" {
-		json.Unmarshal([]byte(hostFeatureEntitlementJson), hostFeatureEntitlement)
-	}
-	return int(status)
+	status, _ := getHostFeatureEntitlement(name, hostFeatureEntitlement)
+	return status
 }
 
 // GetHostProductMetadata gets the value of the product metadata.
@@ -278,12 +211,7 @@ func GetHostFeatureEntitlement(name string, hostFeatureEntitlement *HostFeatureE
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
 func GetHostProductMetadata(key string, value *string) int {
-	cKey := goToCString(key)
-	var cValue = getCArray()
-	status := C.GetHostProductMetadata(cKey, &cValue[0], maxCArrayLength)
-	*value = ctoGoString(&cValue[0])
-	freeCString(cKey)
-	return int(status)
+	return currentBackend().GetHostProductMetadata(key, value)
 }
 
 // GetHostLicenseMetadata gets the value of the license metadata field associated with the LexFloatServer license.
@@ -294,12 +222,7 @@ func GetHostProductMetadata(key string, value *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
 func GetHostLicenseMetadata(key string, value *string) int {
-	cKey := goToCString(key)
-	var cValue = getCArray()
-	status := C.GetHostLicenseMetadata(cKey, &cValue[0], maxCArrayLength)
-	*value = ctoGoString(&cValue[0])
-	freeCString(cKey)
-	return int(status)
+	return currentBackend().GetHostLicenseMetadata(key, value)
 }
 
 // GetHostLicenseMeterAttribute gets the license meter attribute allowed uses and total uses associated with the LexFloatServer license.
@@ -312,16 +235,7 @@ func GetHostLicenseMetadata(key string, value *string) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_METER_ATTRIBUTE_NOT_FOUND
 func GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *uint64, grossUses *uint64) int {
-	cName := goToCString(name)
-	var cAllowedUses C.int64_t
-	var cTotalUses C.uint64_t
-	var cGrossUses C.uint64_t
-	status := C.GetHostLicenseMeterAttribute(cName, &cAllowedUses, &cTotalUses, &cGrossUses)
-	*allowedUses = int64(cAllowedUses)
-	*totalUses = uint64(cTotalUses)
-	*grossUses = uint64(cGrossUses)
-	freeCString(cName)
-	return int(status)
+	return currentBackend().GetHostLicenseMeterAttribute(name, allowedUses, totalUses, grossUses)
 }
 
 // GetHostLicenseExpiryDate gets the license expiry date timestamp of the LexFloatServer license.
@@ -331,10 +245,7 @@ func GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *ui
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE
 func GetHostLicenseExpiryDate(expiryDate *uint) int {
-	var cExpiryDate C.uint
-	status := C.GetHostLicenseExpiryDate(&cExpiryDate)
-	*expiryDate = uint(cExpiryDate)
-	return int(status)
+	return currentBackend().GetHostLicenseExpiryDate(expiryDate)
 }
 
 // GetFloatingClientMeterAttributeUses gets the meter attribute uses consumed by the floating client.
@@ -345,12 +256,7 @@ func GetHostLicenseExpiryDate(expiryDate *uint) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_METER_ATTRIBUTE_NOT_FOUND
 func GetFloatingClientMeterAttributeUses(name string, uses *uint) int {
-	cName := goToCString(name)
-	var cUses C.uint
-	status := C.GetFloatingClientMeterAttributeUses(cName, &cUses)
-	*uses = uint(cUses)
-	freeCString(cName)
-	return int(status)
+	return currentBackend().GetFloatingClientMeterAttributeUses(name, uses)
 }
 
 // GetFloatingClientMetadata gets the value of the floating client metadata.
@@ -361,13 +267,7 @@ func GetFloatingClientMeterAttributeUses(name string, uses *uint) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
 func GetFloatingClientMetadata(key string, value *string) int {
-	cKey := goToCString(key)
-	var cValue = getCArray()
-	status := C.GetFloatingClientMetadata(cKey, &cValue[0], maxCArrayLength)
-	*value = ctoGoString(&cValue[0])
-	freeCString(cKey)
-	return int(status)
-
+	return currentBackend().GetFloatingClientMetadata(key, value)
 }
 
 // RequestFloatingLicense sends the request to lease the license from the LexFloatServer.
@@ -377,8 +277,7 @@ func GetFloatingClientMetadata(key string, value *string) int {
 // LF_E_SERVER_LICENSE_NOT_ACTIVATED, LF_E_SERVER_TIME_MODIFIED, LF_E_SERVER_LICENSE_SUSPENDED,
 // LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED
 func RequestFloatingLicense() int {
-	status := C.RequestFloatingLicense()
-	return int(status)
+	return currentBackend().RequestFloatingLicense()
 }
 
 // GetFloatingClientLeaseExpiryDate gets the lease expiry date timestamp of the floating client.
@@ -388,23 +287,20 @@ func RequestFloatingLicense() int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE
 func GetFloatingClientLeaseExpiryDate(leaseExpiryDate *uint) int {
-	var cLeaseExpiryDate C.uint
-	status := C.GetFloatingClientLeaseExpiryDate(&cLeaseExpiryDate)
-	*leaseExpiryDate = uint(cLeaseExpiryDate)
-	return int(status)
+	return currentBackend().GetFloatingClientLeaseExpiryDate(leaseExpiryDate)
 }
 
 // DropFloatingLicense sends the request to the LexFloatServer to free the license.
 //
 // Call this function before you exit your application to prevent zombie licenses.
+// Shutdown, HandleShutdown and Run do so for online leases on signals, returns and panics.
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_HOST_URL, LF_E_CALLBACK,
 // LF_E_INET, LF_E_LICENSE_NOT_FOUND, LF_E_CLIENT, LF_E_IP, LF_E_SERVER,
 // LF_E_SERVER_LICENSE_NOT_ACTIVATED, LF_E_SERVER_TIME_MODIFIED, LF_E_SERVER_LICENSE_SUSPENDED,
 // LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED
 func DropFloatingLicense() int {
-	status := C.DropFloatingLicense()
-	return int(status)
+	return currentBackend().DropFloatingLicense()
 }
 
 // HasFloatingLicense checks whether any license has been leased or not. If yes,
@@ -412,8 +308,7 @@ func DropFloatingLicense() int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE
 func HasFloatingLicense() int {
-	status := C.HasFloatingLicense()
-	return int(status)
+	return currentBackend().HasFloatingLicense()
 }
 
 // GetHostConfig gets the host configuration.
@@ -422,17 +317,11 @@ func HasFloatingLicense() int {
 // Parameters:
 // - hostConfig: pointer to the HostConfig struct that receives the value
 //
-// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_HOST_URL, LF_E_BUFFER_SIZE, LF_E_INET, LF_E_CLIENT, LF_E_IP, LF_E_SERVER
+// Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_HOST_URL, LF_E_BUFFER_SIZE, LF_E_INET, LF_E_CLIENT, LF_E_IP, LF_E_SERVER,
+// LF_FAIL if the JSON returned by the library cannot be decoded
 func GetHostConfig(hostConfig *HostConfig) int {
-	var cHostConfig = getCArray()
-	hostConfigJson := ""
-	status := C.GetHostConfigInternal(&cHostConfig[0], maxCArrayLength)
-	hostConfigJson = strings.TrimRight(ctoGoString(&cHostConfig[0]), "\x00")
-	if hostConfigJson != "" {
-		config := []byte(hostConfigJson)
-		json.Unmarshal(config, hostConfig)
-	}
-	return int(status)
+	status, _ := getJSON("GetHostConfig", currentBackend().GetHostConfigInternal, hostConfig)
+	return status
 }
 
 // GetFloatingLicenseMode gets the mode of the floating license (online or offline).
@@ -442,10 +331,7 @@ func GetHostConfig(hostConfig *HostConfig) int {
 //
 // Returns: LF_OK, LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE
 func GetFloatingLicenseMode(mode *string) int {
-	var cMode = getCArray()
-	status := C.GetFloatingLicenseMode(&cMode[0], maxCArrayLength)
-	*mode= ctoGoString(&cMode[0])
-	return int(status)
+	return currentBackend().GetFloatingLicenseMode(mode)
 }
 
 // RequestOfflineFloatingLicense sends the request to lease the license from the LexFloatServer for offline usage.
@@ -453,7 +339,7 @@ func GetFloatingLicenseMode(mode *string) int {
 // The maximum value of lease duration is configured in the config.yml of LexFloatServer 
 //
 // Parameters:
-// - leaseDuration: value of the lease duration.
+// - leaseDuration: value of the lease duration in seconds. See also RequestOfflineFloatingLicenseFor.
 //
 // Returns: LF_OK, LF_FAIL, LF_E_PRODUCT_ID, LF_E_LICENSE_EXISTS, LF_E_HOST_URL,
 // LF_E_LICENSE_LIMIT_REACHED, LF_E_INET, LF_E_TIME, LF_E_CLIENT, LF_E_IP, LF_E_SERVER,
@@ -461,9 +347,7 @@ func GetFloatingLicenseMode(mode *string) int {
 // LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED, LF_E_WMIC, LF_E_SYSTEM_PERMISSION,
 // LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY
 func RequestOfflineFloatingLicense(leaseDuration uint) int {
-    cLeaseDuration := (C.uint)(leaseDuration)
-    status := C.RequestOfflineFloatingLicense(cLeaseDuration)
-    return int(status)
+	return currentBackend().RequestOfflineFloatingLicense(leaseDuration)
 }
 
 // IncrementFloatingClientMeterAttributeUses increments the meter attribute uses of the floating client.
@@ -477,11 +361,7 @@ func RequestOfflineFloatingLicense(leaseDuration uint) int {
 // LF_E_SERVER_LICENSE_NOT_ACTIVATED, LF_E_SERVER_TIME_MODIFIED, LF_E_SERVER_LICENSE_SUSPENDED,
 // LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED
 func IncrementFloatingClientMeterAttributeUses(name string, increment uint) int {
-	cName := goToCString(name)
-	cIncrement := (C.uint)(increment)
-	status := C.IncrementFloatingClientMeterAttributeUses(cName, cIncrement)
-	freeCString(cName)
-	return int(status)
+	return currentBackend().IncrementFloatingClientMeterAttributeUses(name, increment)
 }
     
 // DecrementFloatingClientMeterAttributeUses decrements the meter attribute uses of the floating client.
@@ -495,11 +375,7 @@ func IncrementFloatingClientMeterAttributeUses(name string, increment uint) int
 // LF_E_SERVER_LICENSE_NOT_ACTIVATED, LF_E_SERVER_TIME_MODIFIED, LF_E_SERVER_LICENSE_SUSPENDED,
 // LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED
 func DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int {
-	cName := goToCString(name)
-	cDecrement := (C.uint)(decrement)
-	status := C.DecrementFloatingClientMeterAttributeUses(cName, cDecrement)
-	freeCString(cName)
-	return int(status)
+	return currentBackend().DecrementFloatingClientMeterAttributeUses(name, decrement)
 }
 
 // ResetFloatingClientMeterAttributeUses resets the meter attribute uses consumed by the floating client.
@@ -511,8 +387,5 @@ func DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int
 // LF_E_INET, LF_E_LICENSE_NOT_FOUND, LF_E_CLIENT, LF_E_IP, LF_E_SERVER, LF_E_SERVER_LICENSE_NOT_ACTIVATED,
 // LF_E_SERVER_TIME_MODIFIED, LF_E_SERVER_LICENSE_SUSPENDED, LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER, LF_E_SERVER_LICENSE_EXPIRED
 func ResetFloatingClientMeterAttributeUses(name string) int {
-	cName := goToCString(name)
-	status := C.ResetFloatingClientMeterAttributeUses(cName)
-	freeCString(cName)
-	return int(status)
+	return currentBackend().ResetFloatingClientMeterAttributeUses(name)
 }
@@ -0,0 +1,556 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+const (
+	libraryVersion = "lexfloattest"
+
+	maxMetadataKeyLength   = 256
+	maxMetadataValueLength = 4096
+)
+
+type lease struct {
+	mode    lexfloatclient.LeaseMode
+	expiry  time.Time
+	renewAt time.Time
+	revoked bool
+}
+
+func (l *lease) nextEventAt() time.Time {
+	if l.mode == lexfloatclient.LeaseModeOffline {
+		return l.expiry
+	}
+	return l.renewAt
+}
+
+// Backend is a simulated floating client of a Server. It implements
+// lexfloatclient.Backend and is safe for concurrent use.
+type Backend struct {
+	server *Server
+
+	// The fields below are guarded by server.mutex.
+	productID      string
+	hostURL        string
+	permissionFlag uint
+	callback       func(int)
+	metadata       map[string]string
+	lease          *lease
+	meterUses      map[string]uint
+}
+
+var _ lexfloatclient.Backend = (*Backend)(nil)
+
+// processEvent renews or expires the lease of the client. It must be called
+// with server.mutex held and returns the renew callback to invoke, if any.
+func (backend *Backend) processEvent() (func(int), int) {
+	server := backend.server
+	if backend.lease.mode == lexfloatclient.LeaseModeOffline {
+		backend.lease = nil
+		return nil, lexfloatclient.LF_OK
+	}
+	if backend.lease.revoked {
+		backend.lease = nil
+		return backend.callback, lexfloatclient.LF_E_LICENSE_NOT_FOUND
+	}
+	status := server.network(OpRenew, "", 0)
+	if status == lexfloatclient.LF_OK {
+		status = backend.checkServerLicense()
+	}
+	if status == lexfloatclient.LF_OK {
+		backend.grantOnlineLease()
+		return backend.callback, status
+	}
+	if transient(status) {
+		if server.now.Before(backend.lease.expiry) {
+			backend.lease.renewAt = backend.lease.expiry
+			return backend.callback, status
+		}
+		status = lexfloatclient.LF_E_LICENSE_EXPIRED_INET
+	}
+	backend.lease = nil
+	return backend.callback, status
+}
+
+func (backend *Backend) grantOnlineLease() {
+	server := backend.server
+	backend.lease = &lease{
+		mode:    lexfloatclient.LeaseModeOnline,
+		expiry:  server.now.Add(server.config.LeaseDuration),
+		renewAt: server.now.Add(server.config.LeaseDuration / 2),
+	}
+}
+
+func (backend *Backend) lock() func() {
+	backend.server.mutex.Lock()
+	return backend.server.mutex.Unlock
+}
+
+// checkProduct must be called with server.mutex held.
+func (backend *Backend) checkProduct() int {
+	if backend.productID == "" {
+		return lexfloatclient.LF_E_PRODUCT_ID
+	}
+	return lexfloatclient.LF_OK
+}
+
+// checkLicense must be called with server.mutex held.
+func (backend *Backend) checkLicense() int {
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.lease == nil {
+		return lexfloatclient.LF_E_NO_LICENSE
+	}
+	return lexfloatclient.LF_OK
+}
+
+// checkHost must be called with server.mutex held.
+func (backend *Backend) checkHost() int {
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.hostURL == "" {
+		return lexfloatclient.LF_E_HOST_URL
+	}
+	return lexfloatclient.LF_OK
+}
+
+// checkServerLicense must be called with server.mutex held.
+func (backend *Backend) checkServerLicense() int {
+	if backend.server.licenseExpired() {
+		return lexfloatclient.LF_E_SERVER_LICENSE_EXPIRED
+	}
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) SetHostProductId(productId string) int {
+	defer backend.lock()()
+	if productId == "" || (backend.server.config.ProductID != "" && productId != backend.server.config.ProductID) {
+		return lexfloatclient.LF_E_PRODUCT_ID
+	}
+	backend.productID = productId
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) SetHostUrl(hostUrl string) int {
+	defer backend.lock()()
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	parsed, err := url.Parse(hostUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return lexfloatclient.LF_E_HOST_URL
+	}
+	backend.hostURL = hostUrl
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) SetPermissionFlag(flags uint) int {
+	defer backend.lock()()
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if flags != lexfloatclient.LF_USER && flags != lexfloatclient.LF_ALL_USERS {
+		return lexfloatclient.LF_E_INVALID_PERMISSION_FLAG
+	}
+	backend.permissionFlag = flags
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) SetFloatingLicenseCallback(callbackFunction func(int)) int {
+	defer backend.lock()()
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if callbackFunction == nil {
+		return lexfloatclient.LF_E_CALLBACK
+	}
+	backend.callback = callbackFunction
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) SetFloatingClientMetadata(key string, value string) int {
+	defer backend.lock()()
+	if status := backend.checkProduct(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if utf8.RuneCountInString(key) > maxMetadataKeyLength {
+		return lexfloatclient.LF_E_METADATA_KEY_LENGTH
+	}
+	if utf8.RuneCountInString(value) > maxMetadataValueLength {
+		return lexfloatclient.LF_E_METADATA_VALUE_LENGTH
+	}
+	backend.metadata[key] = value
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostConfigInternal(hostConfigJson *string) int {
+	defer backend.lock()()
+	if status := backend.checkHost(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if status := backend.server.network(OpGetHostConfig, "", 0); status != lexfloatclient.LF_OK {
+		return status
+	}
+	hostConfig := lexfloatclient.HostConfig{
+		MaxOfflineLeaseDuration: int(backend.server.config.MaxOfflineLeaseDuration / time.Second),
+	}
+	return marshal(hostConfig, hostConfigJson)
+}
+
+func (backend *Backend) GetFloatingClientLibraryVersion(version *string) int {
+	*version = libraryVersion
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostProductVersionName(name *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lexfloatclient.LF_E_PRODUCT_VERSION_NOT_LINKED
+}
+
+func (backend *Backend) GetHostProductVersionDisplayName(displayName *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lexfloatclient.LF_E_PRODUCT_VERSION_NOT_LINKED
+}
+
+func (backend *Backend) GetHostProductVersionFeatureFlag(name string, enabled *bool, data *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lexfloatclient.LF_E_PRODUCT_VERSION_NOT_LINKED
+}
+
+func (backend *Backend) GetHostLicenseEntitlementSetName(name *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.server.config.EntitlementSetName == "" {
+		return lexfloatclient.LF_E_ENTITLEMENT_SET_NOT_LINKED
+	}
+	*name = backend.server.config.EntitlementSetName
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostLicenseEntitlementSetDisplayName(displayName *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.server.config.EntitlementSetName == "" {
+		return lexfloatclient.LF_E_ENTITLEMENT_SET_NOT_LINKED
+	}
+	*displayName = backend.server.config.EntitlementSetDisplayName
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostLicenseEntitlementSetTier(tier *int64) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.server.config.EntitlementSetName == "" {
+		return lexfloatclient.LF_E_ENTITLEMENT_SET_NOT_LINKED
+	}
+	*tier = backend.server.config.EntitlementSetTier
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostFeatureEntitlementsInternal(hostFeatureEntitlementsJson *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	entitlements := backend.server.config.Entitlements
+	if entitlements == nil {
+		entitlements = []lexfloatclient.HostFeatureEntitlement{}
+	}
+	return marshal(entitlements, hostFeatureEntitlementsJson)
+}
+
+func (backend *Backend) GetHostFeatureEntitlementInternal(name string, hostFeatureEntitlementJson *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	for _, entitlement := range backend.server.config.Entitlements {
+		if entitlement.FeatureName == name {
+			return marshal(entitlement, hostFeatureEntitlementJson)
+		}
+	}
+	return lexfloatclient.LF_E_FEATURE_ENTITLEMENT_NOT_FOUND
+}
+
+func (backend *Backend) GetHostProductMetadata(key string, value *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lookup(backend.server.config.ProductMetadata, key, value)
+}
+
+func (backend *Backend) GetHostLicenseMetadata(key string, value *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lookup(backend.server.config.LicenseMetadata, key, value)
+}
+
+func (backend *Backend) GetHostLicenseMeterAttribute(name string, allowedUses *int64, totalUses *uint64, grossUses *uint64) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	meter, ok := backend.server.meters[name]
+	if !ok {
+		return lexfloatclient.LF_E_METER_ATTRIBUTE_NOT_FOUND
+	}
+	*allowedUses = meter.allowedUses
+	*totalUses = meter.totalUses
+	*grossUses = meter.grossUses
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetHostLicenseExpiryDate(expiryDate *uint) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	*expiryDate = unixTime(backend.server.config.LicenseExpiry)
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetFloatingClientMeterAttributeUses(name string, uses *uint) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if _, ok := backend.server.meters[name]; !ok {
+		return lexfloatclient.LF_E_METER_ATTRIBUTE_NOT_FOUND
+	}
+	*uses = backend.meterUses[name]
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetFloatingClientMetadata(key string, value *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	return lookup(backend.metadata, key, value)
+}
+
+func (backend *Backend) RequestFloatingLicense() int {
+	defer backend.lock()()
+	if status := backend.checkHost(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.callback == nil {
+		return lexfloatclient.LF_E_CALLBACK
+	}
+	if backend.lease != nil {
+		return lexfloatclient.LF_E_LICENSE_EXISTS
+	}
+	if status := backend.server.network(OpRequestFloatingLicense, "", 0); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if status := backend.checkServerLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if !backend.server.seatAvailable() {
+		return lexfloatclient.LF_E_LICENSE_LIMIT_REACHED
+	}
+	backend.grantOnlineLease()
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) GetFloatingClientLeaseExpiryDate(leaseExpiryDate *uint) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	*leaseExpiryDate = unixTime(backend.lease.expiry)
+	return lexfloatclient.LF_OK
+}
+
+// DropFloatingLicense frees the seat of the client. The meter attribute uses
+// consumed by the client are retained.
+func (backend *Backend) DropFloatingLicense() int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if status := backend.checkHost(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if status := backend.server.network(OpDropFloatingLicense, "", 0); status != lexfloatclient.LF_OK {
+		return status
+	}
+	revoked := backend.lease.revoked
+	backend.lease = nil
+	if revoked {
+		return lexfloatclient.LF_E_LICENSE_NOT_FOUND
+	}
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) HasFloatingLicense() int {
+	defer backend.lock()()
+	return backend.checkLicense()
+}
+
+func (backend *Backend) GetFloatingLicenseMode(mode *string) int {
+	defer backend.lock()()
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	*mode = string(backend.lease.mode)
+	return lexfloatclient.LF_OK
+}
+
+// RequestOfflineFloatingLicense leases a seat for leaseDuration seconds.
+func (backend *Backend) RequestOfflineFloatingLicense(leaseDuration uint) int {
+	defer backend.lock()()
+	if status := backend.checkHost(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if backend.lease != nil {
+		return lexfloatclient.LF_E_LICENSE_EXISTS
+	}
+	if status := backend.server.network(OpRequestOfflineFloatingLicense, "", 0); status != lexfloatclient.LF_OK {
+		return status
+	}
+	if status := backend.checkServerLicense(); status != lexfloatclient.LF_OK {
+		return status
+	}
+	server := backend.server
+	if server.config.MaxOfflineLeaseDuration <= 0 {
+		return lexfloatclient.LF_E_OFFLINE_FLOATING_LICENSE_NOT_ALLOWED
+	}
+	duration := time.Duration(leaseDuration) * time.Second
+	if duration <= 0 || duration > server.config.MaxOfflineLeaseDuration {
+		return lexfloatclient.LF_E_MAX_OFFLINE_LEASE_DURATION_EXCEEDED
+	}
+	expiry := server.now.Add(duration)
+	if !server.config.LicenseExpiry.IsZero() && expiry.After(server.config.LicenseExpiry) {
+		return lexfloatclient.LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY
+	}
+	if !server.seatAvailable() {
+		return lexfloatclient.LF_E_LICENSE_LIMIT_REACHED
+	}
+	backend.lease = &lease{mode: lexfloatclient.LeaseModeOffline, expiry: expiry}
+	return lexfloatclient.LF_OK
+}
+
+// meter returns the meter attribute for a meter call that leaves the total
+// uses of the meter attribute at uses. It must be called with server.mutex
+// held.
+func (backend *Backend) meter(op Op, name string, uses func(*meter) uint64) (*meter, int) {
+	if status := backend.checkLicense(); status != lexfloatclient.LF_OK {
+		return nil, status
+	}
+	if status := backend.checkHost(); status != lexfloatclient.LF_OK {
+		return nil, status
+	}
+	meter, ok := backend.server.meters[name]
+	if !ok {
+		return nil, lexfloatclient.LF_E_METER_ATTRIBUTE_NOT_FOUND
+	}
+	if status := backend.server.network(op, name, uses(meter)); status != lexfloatclient.LF_OK {
+		return nil, status
+	}
+	if status := backend.checkServerLicense(); status != lexfloatclient.LF_OK {
+		return nil, status
+	}
+	return meter, lexfloatclient.LF_OK
+}
+
+func (backend *Backend) IncrementFloatingClientMeterAttributeUses(name string, increment uint) int {
+	defer backend.lock()()
+	meter, status := backend.meter(OpIncrementFloatingClientMeterAttributeUses, name, func(meter *meter) uint64 {
+		return meter.totalUses + uint64(increment)
+	})
+	if status != lexfloatclient.LF_OK {
+		return status
+	}
+	if meter.allowedUses >= 0 && meter.totalUses+uint64(increment) > uint64(meter.allowedUses) {
+		return lexfloatclient.LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED
+	}
+	meter.totalUses += uint64(increment)
+	meter.grossUses += uint64(increment)
+	backend.meterUses[name] += increment
+	return lexfloatclient.LF_OK
+}
+
+// DecrementFloatingClientMeterAttributeUses decrements the uses of the client.
+// If the decrement is more than the current uses, it resets the uses to 0.
+func (backend *Backend) DecrementFloatingClientMeterAttributeUses(name string, decrement uint) int {
+	defer backend.lock()()
+	meter, status := backend.meter(OpDecrementFloatingClientMeterAttributeUses, name, func(meter *meter) uint64 {
+		return meter.totalUses
+	})
+	if status != lexfloatclient.LF_OK {
+		return status
+	}
+	if decrement > backend.meterUses[name] {
+		decrement = backend.meterUses[name]
+	}
+	backend.meterUses[name] -= decrement
+	meter.totalUses -= uint64(decrement)
+	return lexfloatclient.LF_OK
+}
+
+func (backend *Backend) ResetFloatingClientMeterAttributeUses(name string) int {
+	defer backend.lock()()
+	meter, status := backend.meter(OpResetFloatingClientMeterAttributeUses, name, func(meter *meter) uint64 {
+		return meter.totalUses
+	})
+	if status != lexfloatclient.LF_OK {
+		return status
+	}
+	meter.totalUses -= uint64(backend.meterUses[name])
+	backend.meterUses[name] = 0
+	return lexfloatclient.LF_OK
+}
+
+func lookup(values map[string]string, key string, value *string) int {
+	found, ok := values[key]
+	if !ok {
+		return lexfloatclient.LF_E_METADATA_KEY_NOT_FOUND
+	}
+	*value = found
+	return lexfloatclient.LF_OK
+}
+
+func marshal(v interface{}, out *string) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return lexfloatclient.LF_FAIL
+	}
+	*out = string(data)
+	return lexfloatclient.LF_OK
+}
+
+func unixTime(t time.Time) uint {
+	if t.IsZero() {
+		return 0
+	}
+	return uint(t.Unix())
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+// Op identifies a network-bound operation of the simulated library that a
+// Fault can fail.
+type Op string
+
+const (
+	OpRequestFloatingLicense                    Op = "RequestFloatingLicense"
+	OpRequestOfflineFloatingLicense             Op = "RequestOfflineFloatingLicense"
+	OpDropFloatingLicense                       Op = "DropFloatingLicense"
+	OpGetHostConfig                             Op = "GetHostConfig"
+	OpIncrementFloatingClientMeterAttributeUses Op = "IncrementFloatingClientMeterAttributeUses"
+	OpDecrementFloatingClientMeterAttributeUses Op = "DecrementFloatingClientMeterAttributeUses"
+	OpResetFloatingClientMeterAttributeUses     Op = "ResetFloatingClientMeterAttributeUses"
+
+	// OpRenew is the lease renew request sent by the library in the
+	// background. Its status is passed to the renew callback.
+	OpRenew Op = "Renew"
+)
+
+var ops = map[Op]bool{
+	OpRequestFloatingLicense:                    true,
+	OpRequestOfflineFloatingLicense:             true,
+	OpDropFloatingLicense:                       true,
+	OpGetHostConfig:                             true,
+	OpIncrementFloatingClientMeterAttributeUses: true,
+	OpDecrementFloatingClientMeterAttributeUses: true,
+	OpResetFloatingClientMeterAttributeUses:     true,
+	OpRenew:                                     true,
+}
+
+// valid reports whether op is one of the declared Op constants.
+func (op Op) valid() bool {
+	return ops[op]
+}
+
+// Fault makes an operation fail with a status code. All conditions that are
+// set must hold for the fault to fire.
+//
+// A renew that fails with a network status such as LF_E_INET keeps the lease
+// and is retried when the lease expires; if the retry fails too, the renew
+// callback receives LF_E_LICENSE_EXPIRED_INET and the lease is lost. Any other
+// failing renew status loses the lease immediately.
+type Fault struct {
+	// Op is the operation to fail.
+	Op Op
+
+	// Status is the status code returned by the operation.
+	Status int
+
+	// Call restricts the fault to the n-th call of Op, counted from 1 across
+	// all clients of the server. Zero matches every call.
+	Call int
+
+	// After restricts the fault to calls made once the virtual clock has
+	// advanced by at least After since the start of the server.
+	After time.Duration
+
+	// Meter restricts meter operations to the named meter attribute.
+	Meter string
+
+	// AtUses restricts increments to those that would take the total uses of
+	// the meter attribute above AtUses.
+	AtUses uint64
+
+	// Times limits how often the fault fires. Zero means unlimited.
+	Times int
+
+	fired int
+}
+
+func (fault *Fault) matches(server *Server, op Op, call int, meter string, uses uint64) bool {
+	if fault.Op != op {
+		return false
+	}
+	if fault.Times > 0 && fault.fired >= fault.Times {
+		return false
+	}
+	if fault.Call > 0 && fault.Call != call {
+		return false
+	}
+	if server.now.Before(server.start.Add(fault.After)) {
+		return false
+	}
+	if fault.Meter != "" && fault.Meter != meter {
+		return false
+	}
+	if fault.AtUses > 0 && uses <= fault.AtUses {
+		return false
+	}
+	return true
+}
+
+// AddFault installs a fault on the server.
+func (server *Server) AddFault(fault Fault) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = append(server.faults, &fault)
+}
+
+// ClearFaults removes all faults from the server.
+func (server *Server) ClearFaults() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = nil
+}
+
+// SetReachable makes the server reachable or unreachable. While the server is
+// unreachable every network-bound operation fails with LF_E_INET.
+func (server *Server) SetReachable(reachable bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.unreachable = !reachable
+}
+
+// Calls returns how often op has been called.
+func (server *Server) Calls(op Op) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.calls[op]
+}
+
+// network records a call of op and returns the status injected by
+// reachability or a fault, or LF_OK. uses is the total uses of the meter
+// attribute after the call. It must be called with server.mutex held.
+func (server *Server) network(op Op, meter string, uses uint64) int {
+	server.calls[op]++
+	if server.unreachable {
+		return lexfloatclient.LF_E_INET
+	}
+	for _, fault := range server.faults {
+		if fault.matches(server, op, server.calls[op], meter, uses) {
+			fault.fired++
+			return fault.Status
+		}
+	}
+	return lexfloatclient.LF_OK
+}
+
+// transient reports whether a failed renew keeps the lease.
+func transient(status int) bool {
+	code := lexfloatclient.StatusCode(status)
+	return code != lexfloatclient.ErrLicenseExpiredInet && code.Category() == lexfloatclient.CategoryNetwork && code.Retryable()
+}
@@ -0,0 +1,403 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+// Action changes the state of a Server at a scenario step.
+type Action func(server *Server)
+
+// Step runs an action once the virtual clock has advanced by At since the
+// start of the server.
+type Step struct {
+	At     time.Duration
+	Action Action
+}
+
+// Scenario is a declarative test scenario: the configuration of a server,
+// the faults it injects and the steps that change its state over virtual
+// time.
+//
+//	scenario := lexfloattest.Scenario{
+//		Config: lexfloattest.Config{LicenseLimit: 5},
+//		Faults: []lexfloattest.Fault{
+//			{Op: lexfloattest.OpRenew, Call: 3, Status: lexfloatclient.LF_E_LICENSE_EXPIRED_INET},
+//		},
+//		Steps: []lexfloattest.Step{
+//			{At: 2 * time.Hour, Action: lexfloattest.SetReachable(false)},
+//		},
+//	}
+//	server := scenario.NewServer()
+//
+// Scenarios can also be loaded from JSON or YAML files with LoadScenario.
+type Scenario struct {
+	Name   string
+	Config Config
+	Faults []Fault
+	Steps  []Step
+}
+
+// NewServer returns a server configured by the scenario. The steps of the
+// scenario run as the virtual clock of the server advances.
+func (scenario Scenario) NewServer() *Server {
+	server := NewServer(scenario.Config)
+	for _, fault := range scenario.Faults {
+		server.AddFault(fault)
+	}
+	server.AddSteps(scenario.Steps...)
+	return server
+}
+
+// AddSteps schedules scenario steps on the server. Steps whose time has
+// already passed run on the next call of Advance.
+func (server *Server) AddSteps(steps ...Step) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.steps = append(server.steps, steps...)
+	sort.SliceStable(server.steps, func(i, j int) bool {
+		return server.steps[i].At < server.steps[j].At
+	})
+}
+
+// SetReachable returns an action that makes the server reachable or
+// unreachable.
+func SetReachable(reachable bool) Action {
+	return func(server *Server) {
+		server.SetReachable(reachable)
+	}
+}
+
+// RevokeLeases returns an action that revokes all online leases.
+func RevokeLeases() Action {
+	return func(server *Server) {
+		server.RevokeLeases()
+	}
+}
+
+// AddFault returns an action that installs a fault.
+func AddFault(fault Fault) Action {
+	return func(server *Server) {
+		server.AddFault(fault)
+	}
+}
+
+// ClearFaults returns an action that removes all faults.
+func ClearFaults() Action {
+	return func(server *Server) {
+		server.ClearFaults()
+	}
+}
+
+// SetLicenseExpiry returns an action that sets the expiry date of the server
+// license.
+func SetLicenseExpiry(expiry time.Time) Action {
+	return func(server *Server) {
+		server.SetLicenseExpiry(expiry)
+	}
+}
+
+// SetEntitlements returns an action that replaces the feature entitlements of
+// the server license.
+func SetEntitlements(entitlements []lexfloatclient.HostFeatureEntitlement) Action {
+	return func(server *Server) {
+		server.SetEntitlements(entitlements)
+	}
+}
+
+// SetLicenseMetadata returns an action that sets a license metadata field.
+func SetLicenseMetadata(key string, value string) Action {
+	return func(server *Server) {
+		server.SetLicenseMetadata(key, value)
+	}
+}
+
+// LoadScenario reads a scenario from a JSON or YAML file. See ParseScenario
+// for the format.
+func LoadScenario(path string) (Scenario, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Scenario{}, err
+	}
+	return ParseScenario(data)
+}
+
+// ParseScenario parses a scenario in JSON or YAML form. A document that
+// starts with "{" is JSON; any other document is YAML.
+//
+// Parsing is strict: unknown keys, unknown ops, unknown actions and unknown
+// status names are errors, so that a typo cannot silently disable a fault.
+// Durations use the format of
+// time.ParseDuration, times use RFC 3339 and status codes are either numbers
+// or names such as "LF_E_INET":
+//
+//	{
+//	  "name": "third renew fails",
+//	  "config": {"licenseLimit": 5, "leaseDuration": "30m", "meters": {"api_calls": 1000}},
+//	  "faults": [
+//	    {"op": "Renew", "call": 3, "status": "LF_E_LICENSE_EXPIRED_INET"},
+//	    {"op": "RequestFloatingLicense", "after": "2h", "status": "LF_E_SERVER_LICENSE_SUSPENDED"},
+//	    {"op": "IncrementFloatingClientMeterAttributeUses", "meter": "api_calls", "atUses": 100,
+//	     "status": "LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED"}
+//	  ],
+//	  "steps": [
+//	    {"at": "1h", "action": "setReachable", "reachable": false},
+//	    {"at": "90m", "action": "setReachable", "reachable": true}
+//	  ]
+//	}
+//
+// The same scenario in YAML:
+//
+//	name: third renew fails
+//	config:
+//	  licenseLimit: 5
+//	  leaseDuration: 30m
+//	  meters: {api_calls: 1000}
+//	faults:
+//	  - {op: Renew, call: 3, status: LF_E_LICENSE_EXPIRED_INET}
+//	  - {op: RequestFloatingLicense, after: 2h, status: LF_E_SERVER_LICENSE_SUSPENDED}
+//	  - op: IncrementFloatingClientMeterAttributeUses
+//	    meter: api_calls
+//	    atUses: 100
+//	    status: LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED
+//	steps:
+//	  - {at: 1h, action: setReachable, reachable: false}
+//	  - {at: 90m, action: setReachable, reachable: true}
+//
+// YAML documents may use block and flow collections, quoted and plain scalars
+// and comments. Anchors, aliases, tags, block scalars, multi-line scalars and
+// multiple documents are not supported, as this module has no dependencies
+// outside the standard library. Plain scalars such as 10 and true are numbers
+// and booleans, so entitlement and metadata values that look like them must
+// be quoted.
+//
+// The step actions are setReachable, revokeLeases, clearFaults,
+// setLicenseExpiry (with "expiry"), setEntitlements (with "entitlements"),
+// setLicenseMetadata (with "key" and "value") and addFault (with "fault").
+func ParseScenario(data []byte) (Scenario, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var err error
+		if data, err = yamlToJSON(data); err != nil {
+			return Scenario{}, fmt.Errorf("lexfloattest: invalid scenario: %w", err)
+		}
+	}
+	var file scenarioFile
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return Scenario{}, fmt.Errorf("lexfloattest: invalid scenario: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return Scenario{}, fmt.Errorf("lexfloattest: invalid scenario: unexpected data after the scenario object")
+	}
+	scenario := Scenario{Name: file.Name}
+	var err error
+	if scenario.Config, err = file.Config.config(); err != nil {
+		return Scenario{}, err
+	}
+	for i, faultFile := range file.Faults {
+		fault, err := faultFile.fault()
+		if err != nil {
+			return Scenario{}, fmt.Errorf("lexfloattest: fault %d: %w", i, err)
+		}
+		scenario.Faults = append(scenario.Faults, fault)
+	}
+	for i, stepFile := range file.Steps {
+		step, err := stepFile.step()
+		if err != nil {
+			return Scenario{}, fmt.Errorf("lexfloattest: step %d: %w", i, err)
+		}
+		scenario.Steps = append(scenario.Steps, step)
+	}
+	return scenario, nil
+}
+
+type scenarioFile struct {
+	Name   string      `json:"name"`
+	Config configFile  `json:"config"`
+	Faults []faultFile `json:"faults"`
+	Steps  []stepFile  `json:"steps"`
+}
+
+type configFile struct {
+	ProductID                 string                                  `json:"productId"`
+	LicenseLimit              int                                     `json:"licenseLimit"`
+	LeaseDuration             string                                  `json:"leaseDuration"`
+	MaxOfflineLeaseDuration   string                                  `json:"maxOfflineLeaseDuration"`
+	LicenseExpiry             string                                  `json:"licenseExpiry"`
+	EntitlementSetName        string                                  `json:"entitlementSetName"`
+	EntitlementSetDisplayName string                                  `json:"entitlementSetDisplayName"`
+	EntitlementSetTier        int64                                   `json:"entitlementSetTier"`
+	Entitlements              []lexfloatclient.HostFeatureEntitlement `json:"entitlements"`
+	LicenseMetadata           map[string]string                       `json:"licenseMetadata"`
+	ProductMetadata           map[string]string                       `json:"productMetadata"`
+	Meters                    map[string]int64                        `json:"meters"`
+	Start                     string                                  `json:"start"`
+}
+
+func (file configFile) config() (Config, error) {
+	config := Config{
+		ProductID:                 file.ProductID,
+		LicenseLimit:              file.LicenseLimit,
+		EntitlementSetName:        file.EntitlementSetName,
+		EntitlementSetDisplayName: file.EntitlementSetDisplayName,
+		EntitlementSetTier:        file.EntitlementSetTier,
+		Entitlements:              file.Entitlements,
+		LicenseMetadata:           file.LicenseMetadata,
+		ProductMetadata:           file.ProductMetadata,
+		Meters:                    file.Meters,
+	}
+	var err error
+	if config.LeaseDuration, err = parseDuration("leaseDuration", file.LeaseDuration); err != nil {
+		return Config{}, err
+	}
+	if config.MaxOfflineLeaseDuration, err = parseDuration("maxOfflineLeaseDuration", file.MaxOfflineLeaseDuration); err != nil {
+		return Config{}, err
+	}
+	if config.LicenseExpiry, err = parseTime("licenseExpiry", file.LicenseExpiry); err != nil {
+		return Config{}, err
+	}
+	if config.Start, err = parseTime("start", file.Start); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+type faultFile struct {
+	Op     Op              `json:"op"`
+	Status json.RawMessage `json:"status"`
+	Call   int             `json:"call"`
+	After  string          `json:"after"`
+	Meter  string          `json:"meter"`
+	AtUses uint64          `json:"atUses"`
+	Times  int             `json:"times"`
+}
+
+func (file faultFile) fault() (Fault, error) {
+	fault := Fault{
+		Op:     file.Op,
+		Call:   file.Call,
+		Meter:  file.Meter,
+		AtUses: file.AtUses,
+		Times:  file.Times,
+	}
+	if fault.Op == "" {
+		return Fault{}, fmt.Errorf("op is required")
+	}
+	if !fault.Op.valid() {
+		return Fault{}, fmt.Errorf("unknown op %q", fault.Op)
+	}
+	var err error
+	if fault.Status, err = parseStatus(file.Status); err != nil {
+		return Fault{}, err
+	}
+	if fault.After, err = parseDuration("after", file.After); err != nil {
+		return Fault{}, err
+	}
+	return fault, nil
+}
+
+type stepFile struct {
+	At           string                                  `json:"at"`
+	Action       string                                  `json:"action"`
+	Reachable    bool                                    `json:"reachable"`
+	Expiry       string                                  `json:"expiry"`
+	Entitlements []lexfloatclient.HostFeatureEntitlement `json:"entitlements"`
+	Key          string                                  `json:"key"`
+	Value        string                                  `json:"value"`
+	Fault        *faultFile                              `json:"fault"`
+}
+
+func (file stepFile) step() (Step, error) {
+	var step Step
+	var err error
+	if step.At, err = parseDuration("at", file.At); err != nil {
+		return Step{}, err
+	}
+	switch file.Action {
+	case "setReachable":
+		step.Action = SetReachable(file.Reachable)
+	case "revokeLeases":
+		step.Action = RevokeLeases()
+	case "clearFaults":
+		step.Action = ClearFaults()
+	case "setLicenseExpiry":
+		expiry, err := parseTime("expiry", file.Expiry)
+		if err != nil {
+			return Step{}, err
+		}
+		step.Action = SetLicenseExpiry(expiry)
+	case "setEntitlements":
+		step.Action = SetEntitlements(file.Entitlements)
+	case "setLicenseMetadata":
+		step.Action = SetLicenseMetadata(file.Key, file.Value)
+	case "addFault":
+		if file.Fault == nil {
+			return Step{}, fmt.Errorf("addFault requires a fault")
+		}
+		fault, err := file.Fault.fault()
+		if err != nil {
+			return Step{}, err
+		}
+		step.Action = AddFault(fault)
+	default:
+		return Step{}, fmt.Errorf("unknown action %q", file.Action)
+	}
+	return step, nil
+}
+
+func parseDuration(field string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return d, nil
+}
+
+func parseTime(field string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return t, nil
+}
+
+// parseStatus parses a status code given as a number or as a name.
+func parseStatus(raw json.RawMessage) (int, error) {
+	if len(raw) == 0 {
+		return 0, fmt.Errorf("status is required")
+	}
+	var status int
+	if err := json.Unmarshal(raw, &status); err == nil {
+		return status, nil
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return 0, fmt.Errorf("invalid status %s", raw)
+	}
+	for code := lexfloatclient.LF_OK; code <= lexfloatclient.LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY; code++ {
+		if lexfloatclient.StatusCode(code).String() == name {
+			return code, nil
+		}
+	}
+	if status, err := strconv.Atoi(name); err == nil {
+		return status, nil
+	}
+	return 0, fmt.Errorf("unknown status %q", name)
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+const testScenario = `{
+  "name": "second renew fails",
+  "config": {"licenseLimit": 5, "leaseDuration": "30m", "meters": {"api_calls": 1000}},
+  "faults": [
+    {"op": "Renew", "call": 2, "status": "LF_E_SERVER_LICENSE_SUSPENDED"},
+    {"op": "IncrementFloatingClientMeterAttributeUses", "meter": "api_calls", "atUses": 100, "status": 75}
+  ],
+  "steps": [
+    {"at": "1h", "action": "setReachable", "reachable": false},
+    {"at": "90m", "action": "setReachable", "reachable": true}
+  ]
+}`
+
+// testScenarioYAML is testScenario in YAML.
+const testScenarioYAML = `# The second renew fails.
+name: second renew fails
+config:
+  licenseLimit: 5
+  leaseDuration: 30m
+  meters: {api_calls: 1000}
+faults:
+- {op: Renew, call: 2, status: LF_E_SERVER_LICENSE_SUSPENDED}
+- op: IncrementFloatingClientMeterAttributeUses
+  meter: api_calls
+  atUses: 100
+  status: 75 # LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED
+steps:
+  - at: 1h
+    action: setReachable
+    reachable: false
+  - {at: 90m, action: setReachable, reachable: true}
+`
+
+func TestParseScenario(t *testing.T) {
+	scenario, err := ParseScenario([]byte(testScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scenario.Name != "second renew fails" {
+		t.Errorf("Name = %q", scenario.Name)
+	}
+	if scenario.Config.LicenseLimit != 5 || scenario.Config.LeaseDuration != 30*time.Minute {
+		t.Errorf("Config = %+v", scenario.Config)
+	}
+	wantFaults := []Fault{
+		{Op: OpRenew, Call: 2, Status: lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED},
+		{Op: OpIncrementFloatingClientMeterAttributeUses, Meter: "api_calls", AtUses: 100, Status: 75},
+	}
+	if !reflect.DeepEqual(scenario.Faults, wantFaults) {
+		t.Errorf("Faults = %+v, want %+v", scenario.Faults, wantFaults)
+	}
+	if len(scenario.Steps) != 2 || scenario.Steps[0].At != time.Hour || scenario.Steps[1].At != 90*time.Minute {
+		t.Errorf("Steps = %+v", scenario.Steps)
+	}
+}
+
+func TestParseScenarioYAML(t *testing.T) {
+	want, err := ParseScenario([]byte(testScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scenario, err := ParseScenario([]byte(testScenarioYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scenario.Name != want.Name || !reflect.DeepEqual(scenario.Config, want.Config) || !reflect.DeepEqual(scenario.Faults, want.Faults) {
+		t.Errorf("ParseScenario(YAML) = %+v, want %+v", scenario, want)
+	}
+	if len(scenario.Steps) != len(want.Steps) {
+		t.Fatalf("Steps = %+v, want %+v", scenario.Steps, want.Steps)
+	}
+	for i := range scenario.Steps {
+		if scenario.Steps[i].At != want.Steps[i].At {
+			t.Errorf("Steps[%d].At = %v, want %v", i, scenario.Steps[i].At, want.Steps[i].At)
+		}
+	}
+}
+
+func TestParseScenarioRejectsTypos(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		want     string
+	}{
+		{"unknown op", `{"faults": [{"op": "Renw", "status": "LF_E_INET"}]}`, `unknown op "Renw"`},
+		{"unknown top-level key", `{"stepz": []}`, `unknown field "stepz"`},
+		{"unknown fault key", `{"faults": [{"op": "Renew", "status": "LF_E_INET", "cal": 2}]}`, `unknown field "cal"`},
+		{"unknown config key", `{"config": {"licenceLimit": 1}}`, `unknown field "licenceLimit"`},
+		{"unknown action", `{"steps": [{"at": "1m", "action": "setReachble"}]}`, `unknown action "setReachble"`},
+		{"unknown status", `{"faults": [{"op": "Renew", "status": "LF_E_INETT"}]}`, `unknown status "LF_E_INETT"`},
+		{"missing status", `{"faults": [{"op": "Renew"}]}`, `status is required`},
+		{"trailing data", `{} {}`, `unexpected data`},
+		{"YAML unknown op", "faults:\n  - {op: Renw, status: LF_E_INET}", `unknown op "Renw"`},
+		{"YAML unknown top-level key", "stepz: []", `unknown field "stepz"`},
+		{"YAML unknown fault key", "faults:\n  - op: Renew\n    status: LF_E_INET\n    cal: 2", `unknown field "cal"`},
+		{"YAML unknown status", "faults:\n  - {op: Renew, status: LF_E_INETT}", `unknown status "LF_E_INETT"`},
+		{"YAML syntax error", "name: [unterminated", `line 1`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseScenario([]byte(test.scenario))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("error = %v, want it to contain %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestScenarioRun(t *testing.T) {
+	scenario, err := ParseScenario([]byte(testScenario))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := scenario.NewServer()
+	backend, recorder := newClient(t, server)
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	server.Advance(time.Hour)
+	want := []int{lexfloatclient.LF_OK, lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED}
+	if got := recorder.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("statuses = %v, want %v", got, want)
+	}
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_E_INET {
+		t.Errorf("at 1h: RequestFloatingLicense = %d, want LF_E_INET", status)
+	}
+	server.Advance(30 * time.Minute)
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Errorf("at 90m: RequestFloatingLicense = %d, want LF_OK", status)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+// Package lexfloattest provides an in-process simulation of a LexFloatServer
+// and of the LexFloatClient library for tests.
+//
+// A Server models the floating license of a LexFloatServer: its seat limit,
+// lease duration, offline leasing, meter attributes, feature entitlements and
+// metadata. Every Backend created by the server is a floating client that
+// implements lexfloatclient.Backend and can be installed with Install, which
+// also makes the virtual clock of the server the clock of lexfloatclient:
+//
+//	server := lexfloattest.NewServer(lexfloattest.Config{LicenseLimit: 1})
+//	defer server.Install(server.NewBackend())()
+//
+// Time is virtual. It only moves when Advance is called, which renews online
+// leases, invokes the renew callbacks, expires offline leases and runs the
+// steps of a Scenario in order. Faults make network-bound operations and
+// lease renewals fail with scripted status codes.
+//
+// lexfloatclient delivers renew statuses to its listeners asynchronously;
+// call lexfloatclient.WaitForCallbacks after Advance before asserting on them.
+package lexfloattest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+// DefaultLeaseDuration is the lease duration of online leases when
+// Config.LeaseDuration is zero.
+const DefaultLeaseDuration = 30 * time.Minute
+
+// Config configures a simulated LexFloatServer.
+type Config struct {
+	// ProductID is the product id clients must set. Empty accepts any
+	// non-empty product id.
+	ProductID string
+
+	// LicenseLimit is the number of floating licenses (seats). Zero means
+	// unlimited.
+	LicenseLimit int
+
+	// LeaseDuration is the duration of online leases. Leases are renewed
+	// when half of the duration has elapsed.
+	LeaseDuration time.Duration
+
+	// MaxOfflineLeaseDuration is the maximum duration of offline leases.
+	// Zero disallows offline leases.
+	MaxOfflineLeaseDuration time.Duration
+
+	// LicenseExpiry is the expiry date of the server license. The zero
+	// value means the license never expires.
+	LicenseExpiry time.Time
+
+	EntitlementSetName        string
+	EntitlementSetDisplayName string
+	EntitlementSetTier        int64
+	Entitlements              []lexfloatclient.HostFeatureEntitlement
+
+	LicenseMetadata map[string]string
+	ProductMetadata map[string]string
+
+	// Meters maps meter attribute names to their allowed uses. A value of
+	// -1 indicates unlimited allowed uses.
+	Meters map[string]int64
+
+	// Start is the initial time of the virtual clock. The zero value uses
+	// the current time truncated to seconds.
+	Start time.Time
+}
+
+type meter struct {
+	allowedUses int64
+	totalUses   uint64
+	grossUses   uint64
+}
+
+// Server is a simulated LexFloatServer. It is safe for concurrent use.
+type Server struct {
+	mutex       sync.Mutex
+	config      Config
+	start       time.Time
+	now         time.Time
+	meters      map[string]*meter
+	clients     []*Backend
+	unreachable bool
+	faults      []*Fault
+	calls       map[Op]int
+	steps       []Step
+}
+
+// NewServer returns a simulated LexFloatServer.
+func NewServer(config Config) *Server {
+	if config.LeaseDuration <= 0 {
+		config.LeaseDuration = DefaultLeaseDuration
+	}
+	now := config.Start
+	if now.IsZero() {
+		now = time.Now().Truncate(time.Second)
+	}
+	server := &Server{
+		config: config,
+		start:  now,
+		now:    now,
+		meters: make(map[string]*meter),
+		calls:  make(map[Op]int),
+	}
+	for name, allowedUses := range config.Meters {
+		server.meters[name] = &meter{allowedUses: allowedUses}
+	}
+	return server
+}
+
+// NewBackend returns a new floating client of the server.
+func (server *Server) NewBackend() *Backend {
+	backend := &Backend{
+		server:    server,
+		metadata:  make(map[string]string),
+		meterUses: make(map[string]uint),
+	}
+	server.mutex.Lock()
+	server.clients = append(server.clients, backend)
+	server.mutex.Unlock()
+	return backend
+}
+
+// Install makes backend the backend of the lexfloatclient package and the
+// virtual clock of the server its clock, so that the expiry and lease
+// computations of lexfloatclient agree with the server after Advance.
+//
+// Parameters:
+// - backend: a backend of the server, or a wrapper of one
+//
+// Returns: a function that restores the previous backend and clock
+func (server *Server) Install(backend lexfloatclient.Backend) (restore func()) {
+	previousBackend := lexfloatclient.SetBackend(backend)
+	previousClock := lexfloatclient.SetClock(server.Now)
+	return func() {
+		lexfloatclient.SetClock(previousClock)
+		lexfloatclient.SetBackend(previousBackend)
+	}
+}
+
+// Now returns the current time of the virtual clock.
+func (server *Server) Now() time.Time {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.now
+}
+
+// ActiveLeases returns the number of leased floating licenses.
+func (server *Server) ActiveLeases() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.activeLeases()
+}
+
+// SetEntitlements replaces the feature entitlements of the server license.
+func (server *Server) SetEntitlements(entitlements []lexfloatclient.HostFeatureEntitlement) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.config.Entitlements = entitlements
+}
+
+// SetLicenseMetadata sets a license metadata field of the server license.
+func (server *Server) SetLicenseMetadata(key string, value string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.config.LicenseMetadata == nil {
+		server.config.LicenseMetadata = make(map[string]string)
+	}
+	server.config.LicenseMetadata[key] = value
+}
+
+// SetLicenseExpiry sets the expiry date of the server license.
+func (server *Server) SetLicenseExpiry(expiry time.Time) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.config.LicenseExpiry = expiry
+}
+
+// RevokeLeases frees the seats of all online leases on the server side. The
+// next renew of each revoked lease fails with LF_E_LICENSE_NOT_FOUND.
+func (server *Server) RevokeLeases() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for _, client := range server.clients {
+		if client.lease != nil && client.lease.mode == lexfloatclient.LeaseModeOnline {
+			client.lease.revoked = true
+		}
+	}
+}
+
+// Elapsed returns the time the virtual clock has advanced since the start of
+// the server.
+func (server *Server) Elapsed() time.Duration {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.now.Sub(server.start)
+}
+
+// Advance moves the virtual clock forward by d. Lease renewals, offline
+// lease expiries and scenario steps that fall within d are processed in
+// chronological order. Renew callbacks and step actions run on the calling
+// goroutine.
+func (server *Server) Advance(d time.Duration) {
+	server.mutex.Lock()
+	target := server.now.Add(d)
+	server.mutex.Unlock()
+	for {
+		server.mutex.Lock()
+		if len(server.steps) > 0 {
+			step := server.steps[0]
+			at := server.start.Add(step.At)
+			if client, clientAt := server.nextEvent(at); !at.After(target) && (client == nil || !clientAt.Before(at)) {
+				server.steps = server.steps[1:]
+				if at.After(server.now) {
+					server.now = at
+				}
+				server.mutex.Unlock()
+				step.Action(server)
+				continue
+			}
+		}
+		client, at := server.nextEvent(target)
+		if client == nil {
+			server.now = target
+			server.mutex.Unlock()
+			return
+		}
+		server.now = at
+		callback, status := client.processEvent()
+		server.mutex.Unlock()
+		if callback != nil {
+			callback(status)
+		}
+	}
+}
+
+// AdvanceTo moves the virtual clock forward to the given offset from the start
+// of the server. It does nothing if the offset has already passed.
+func (server *Server) AdvanceTo(offset time.Duration) {
+	if d := offset - server.Elapsed(); d > 0 {
+		server.Advance(d)
+	}
+}
+
+// nextEvent returns the client with the earliest pending event at or before
+// target.
+func (server *Server) nextEvent(target time.Time) (*Backend, time.Time) {
+	var next *Backend
+	var nextAt time.Time
+	for _, client := range server.clients {
+		if client.lease == nil {
+			continue
+		}
+		at := client.lease.nextEventAt()
+		if at.After(target) {
+			continue
+		}
+		if next == nil || at.Before(nextAt) {
+			next = client
+			nextAt = at
+		}
+	}
+	return next, nextAt
+}
+
+func (server *Server) activeLeases() int {
+	count := 0
+	for _, client := range server.clients {
+		if client.lease != nil && !client.lease.revoked {
+			count++
+		}
+	}
+	return count
+}
+
+func (server *Server) seatAvailable() bool {
+	return server.config.LicenseLimit <= 0 || server.activeLeases() < server.config.LicenseLimit
+}
+
+func (server *Server) licenseExpired() bool {
+	return !server.config.LicenseExpiry.IsZero() && !server.now.Before(server.config.LicenseExpiry)
+}
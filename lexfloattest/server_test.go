@@ -0,0 +1,220 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+)
+
+// statusRecorder records the statuses passed to a renew callback.
+type statusRecorder struct {
+	mutex    sync.Mutex
+	statuses []int
+}
+
+func (recorder *statusRecorder) callback(status int) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.statuses = append(recorder.statuses, status)
+}
+
+func (recorder *statusRecorder) get() []int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]int(nil), recorder.statuses...)
+}
+
+// newClient returns a configured client of server whose renew statuses are
+// recorded.
+func newClient(t *testing.T, server *Server) (*Backend, *statusRecorder) {
+	t.Helper()
+	backend := server.NewBackend()
+	recorder := &statusRecorder{}
+	if status := backend.SetHostProductId("product"); status != lexfloatclient.LF_OK {
+		t.Fatalf("SetHostProductId: %d", status)
+	}
+	if status := backend.SetHostUrl("http://localhost:8090"); status != lexfloatclient.LF_OK {
+		t.Fatalf("SetHostUrl: %d", status)
+	}
+	if status := backend.SetFloatingLicenseCallback(recorder.callback); status != lexfloatclient.LF_OK {
+		t.Fatalf("SetFloatingLicenseCallback: %d", status)
+	}
+	return backend, recorder
+}
+
+func TestLicenseLimit(t *testing.T) {
+	server := NewServer(Config{LicenseLimit: 2})
+	first, _ := newClient(t, server)
+	second, _ := newClient(t, server)
+	third, _ := newClient(t, server)
+	for i, backend := range []*Backend{first, second} {
+		if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+			t.Fatalf("client %d: RequestFloatingLicense = %d, want LF_OK", i+1, status)
+		}
+	}
+	if status := third.RequestFloatingLicense(); status != lexfloatclient.LF_E_LICENSE_LIMIT_REACHED {
+		t.Fatalf("third client: RequestFloatingLicense = %d, want LF_E_LICENSE_LIMIT_REACHED", status)
+	}
+	if status := first.RequestFloatingLicense(); status != lexfloatclient.LF_E_LICENSE_EXISTS {
+		t.Errorf("second request: RequestFloatingLicense = %d, want LF_E_LICENSE_EXISTS", status)
+	}
+	if got := server.ActiveLeases(); got != 2 {
+		t.Errorf("ActiveLeases = %d, want 2", got)
+	}
+	if status := first.DropFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("DropFloatingLicense = %d, want LF_OK", status)
+	}
+	if status := third.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Errorf("after drop: RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	if got := server.ActiveLeases(); got != 2 {
+		t.Errorf("ActiveLeases = %d, want 2", got)
+	}
+}
+
+func TestRenewCallback(t *testing.T) {
+	server := NewServer(Config{LeaseDuration: 30 * time.Minute})
+	backend, recorder := newClient(t, server)
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("RequestFloatingLicense = %d, want LF_OK", status)
+	}
+
+	// Online leases renew when half of the lease duration has elapsed.
+	server.Advance(time.Hour)
+	want := []int{lexfloatclient.LF_OK, lexfloatclient.LF_OK, lexfloatclient.LF_OK, lexfloatclient.LF_OK}
+	if got := recorder.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("after 1h: statuses = %v, want %v", got, want)
+	}
+
+	// A network failure keeps the lease until it expires, then loses it.
+	server.SetReachable(false)
+	server.Advance(30 * time.Minute)
+	want = append(want, lexfloatclient.LF_E_INET, lexfloatclient.LF_E_LICENSE_EXPIRED_INET)
+	if got := recorder.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unreachable: statuses = %v, want %v", got, want)
+	}
+	if status := backend.HasFloatingLicense(); status != lexfloatclient.LF_E_NO_LICENSE {
+		t.Errorf("HasFloatingLicense = %d, want LF_E_NO_LICENSE", status)
+	}
+
+	// A revoked lease is reported as not found on the next renew.
+	server.SetReachable(true)
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	server.RevokeLeases()
+	server.Advance(15 * time.Minute)
+	want = append(want, lexfloatclient.LF_E_LICENSE_NOT_FOUND)
+	if got := recorder.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("revoked: statuses = %v, want %v", got, want)
+	}
+}
+
+func TestRenewFault(t *testing.T) {
+	server := NewServer(Config{})
+	backend, recorder := newClient(t, server)
+	server.AddFault(Fault{Op: OpRenew, Status: lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED, Call: 2})
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	server.Advance(time.Hour)
+	want := []int{lexfloatclient.LF_OK, lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED}
+	if got := recorder.get(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("statuses = %v, want %v", got, want)
+	}
+	if got := server.Calls(OpRenew); got != 2 {
+		t.Errorf("Calls(OpRenew) = %d, want 2", got)
+	}
+}
+
+func TestOfflineLeaseLimits(t *testing.T) {
+	server := NewServer(Config{MaxOfflineLeaseDuration: time.Hour})
+	backend, recorder := newClient(t, server)
+	if status := backend.RequestOfflineFloatingLicense(7200); status != lexfloatclient.LF_E_MAX_OFFLINE_LEASE_DURATION_EXCEEDED {
+		t.Errorf("2h: RequestOfflineFloatingLicense = %d, want LF_E_MAX_OFFLINE_LEASE_DURATION_EXCEEDED", status)
+	}
+	if status := backend.RequestOfflineFloatingLicense(3600); status != lexfloatclient.LF_OK {
+		t.Fatalf("1h: RequestOfflineFloatingLicense = %d, want LF_OK", status)
+	}
+	var mode string
+	if status := backend.GetFloatingLicenseMode(&mode); status != lexfloatclient.LF_OK || mode != string(lexfloatclient.LeaseModeOffline) {
+		t.Errorf("GetFloatingLicenseMode = %d, %q, want LF_OK, %q", status, mode, lexfloatclient.LeaseModeOffline)
+	}
+
+	// Offline leases are not renewed and expire silently.
+	server.Advance(time.Hour - time.Second)
+	if status := backend.HasFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Errorf("before expiry: HasFloatingLicense = %d, want LF_OK", status)
+	}
+	server.Advance(time.Second)
+	if status := backend.HasFloatingLicense(); status != lexfloatclient.LF_E_NO_LICENSE {
+		t.Errorf("after expiry: HasFloatingLicense = %d, want LF_E_NO_LICENSE", status)
+	}
+	if got := recorder.get(); len(got) != 0 {
+		t.Errorf("statuses = %v, want none", got)
+	}
+
+	server.SetLicenseExpiry(server.Now().Add(30 * time.Minute))
+	if status := backend.RequestOfflineFloatingLicense(3600); status != lexfloatclient.LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY {
+		t.Errorf("past server license expiry: RequestOfflineFloatingLicense = %d, want LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY", status)
+	}
+}
+
+func TestOfflineLeaseNotAllowed(t *testing.T) {
+	server := NewServer(Config{})
+	backend, _ := newClient(t, server)
+	if status := backend.RequestOfflineFloatingLicense(60); status != lexfloatclient.LF_E_OFFLINE_FLOATING_LICENSE_NOT_ALLOWED {
+		t.Errorf("RequestOfflineFloatingLicense = %d, want LF_E_OFFLINE_FLOATING_LICENSE_NOT_ALLOWED", status)
+	}
+}
+
+func TestMeterAttributeUses(t *testing.T) {
+	server := NewServer(Config{Meters: map[string]int64{"exports": 10}})
+	backend, _ := newClient(t, server)
+	if status := backend.RequestFloatingLicense(); status != lexfloatclient.LF_OK {
+		t.Fatalf("RequestFloatingLicense = %d, want LF_OK", status)
+	}
+	if status := backend.IncrementFloatingClientMeterAttributeUses("exports", 8); status != lexfloatclient.LF_OK {
+		t.Fatalf("IncrementFloatingClientMeterAttributeUses(8) = %d, want LF_OK", status)
+	}
+	if status := backend.IncrementFloatingClientMeterAttributeUses("exports", 3); status != lexfloatclient.LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED {
+		t.Errorf("IncrementFloatingClientMeterAttributeUses(3) = %d, want LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED", status)
+	}
+	if status := backend.DecrementFloatingClientMeterAttributeUses("exports", 20); status != lexfloatclient.LF_OK {
+		t.Fatalf("DecrementFloatingClientMeterAttributeUses(20) = %d, want LF_OK", status)
+	}
+	var uses uint
+	if status := backend.GetFloatingClientMeterAttributeUses("exports", &uses); status != lexfloatclient.LF_OK || uses != 0 {
+		t.Errorf("GetFloatingClientMeterAttributeUses = %d, %d, want LF_OK, 0", status, uses)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	server := NewServer(Config{MaxOfflineLeaseDuration: 24 * time.Hour})
+	defer server.Install(server.NewBackend())()
+	if _, err := lexfloatclient.NewClient(lexfloatclient.Options{
+		ProductID: "product",
+		HostURL:   "http://localhost:8090",
+	}); err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := lexfloatclient.RequestOfflineFloatingLicenseFor(24 * time.Hour); err != nil {
+		t.Fatalf("RequestOfflineFloatingLicenseFor: %v", err)
+	}
+	server.Advance(23 * time.Hour)
+	leaseInfo, err := lexfloatclient.CurrentLeaseInfo()
+	if err != nil {
+		t.Fatalf("CurrentLeaseInfo: %v", err)
+	}
+	if leaseInfo.OfflineRemaining != time.Hour {
+		t.Errorf("OfflineRemaining = %v, want 1h", leaseInfo.OfflineRemaining)
+	}
+	if remaining, err := lexfloatclient.TimeUntilLeaseExpiry(); err != nil || remaining != time.Hour {
+		t.Errorf("TimeUntilLeaseExpiry = %v, %v, want 1h", remaining, err)
+	}
+}
@@ -0,0 +1,471 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The scenario files accept the subset of YAML that is needed to write them:
+// block mappings and sequences, flow collections on a single line, plain,
+// single-quoted and double-quoted scalars, and comments. The document is
+// converted to JSON and decoded like a JSON scenario, so that both forms are
+// equally strict. Anchors, aliases, tags, block scalars, multi-line scalars
+// and multiple documents are rejected.
+
+// yamlLine is a line of a YAML document that is neither blank nor a comment.
+type yamlLine struct {
+	number int
+	indent int
+
+	// text is the line without indentation, comment and trailing spaces.
+	text string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// yamlToJSON converts a YAML document to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	lines, err := splitYAMLLines(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return []byte("{}"), nil
+	}
+	parser := &yamlParser{lines: lines}
+	value, err := parser.parseBlock(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(lines) {
+		return nil, yamlError(lines[parser.pos].number, "unexpected indentation")
+	}
+	return json.Marshal(value)
+}
+
+func yamlError(line int, format string, args ...interface{}) error {
+	return fmt.Errorf("yaml: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// splitYAMLLines returns the lines of the document that hold content.
+func splitYAMLLines(document string) ([]yamlLine, error) {
+	var lines []yamlLine
+	for i, text := range strings.Split(document, "\n") {
+		number := i + 1
+		text = strings.TrimRight(stripYAMLComment(strings.TrimSuffix(text, "\r")), " \t")
+		content := strings.TrimLeft(text, " ")
+		if content == "" {
+			continue
+		}
+		if strings.HasPrefix(content, "\t") {
+			return nil, yamlError(number, "tabs cannot be used for indentation")
+		}
+		indent := len(text) - len(content)
+		if indent == 0 && (content == "---" || content == "...") {
+			if len(lines) > 0 || content == "..." {
+				return nil, yamlError(number, "multiple documents are not supported")
+			}
+			continue
+		}
+		if indent == 0 && strings.HasPrefix(content, "%") {
+			return nil, yamlError(number, "directives are not supported")
+		}
+		lines = append(lines, yamlLine{number: number, indent: indent, text: content})
+	}
+	return lines, nil
+}
+
+// stripYAMLComment removes a comment from a line. A # starts a comment at the
+// start of the line or after a space, outside quoted scalars.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\', quote == '\'' && c == '\'' && i+1 < len(line) && line[i+1] == '\'':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.IndexByte(" \t[{,", line[i-1]) >= 0):
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func isYAMLSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock parses the block node whose first line has the given indentation.
+func (parser *yamlParser) parseBlock(indent int) (interface{}, error) {
+	line := parser.lines[parser.pos]
+	if isYAMLSequenceItem(line.text) {
+		return parser.parseSequence(indent)
+	}
+	if _, _, ok, err := splitYAMLMappingEntry(line); err != nil || ok {
+		if err != nil {
+			return nil, err
+		}
+		return parser.parseMapping(indent)
+	}
+	parser.pos++
+	value, err := parseYAMLInline(line.text, line.number)
+	if err != nil {
+		return nil, err
+	}
+	return value, parser.checkNoContinuation(indent)
+}
+
+// checkNoContinuation rejects lines that continue a scalar on the next line.
+func (parser *yamlParser) checkNoContinuation(indent int) error {
+	if parser.pos < len(parser.lines) && parser.lines[parser.pos].indent > indent {
+		return yamlError(parser.lines[parser.pos].number, "unexpected indentation; multi-line values are not supported")
+	}
+	return nil
+}
+
+func (parser *yamlParser) parseSequence(indent int) (interface{}, error) {
+	items := []interface{}{}
+	for parser.pos < len(parser.lines) {
+		line := parser.lines[parser.pos]
+		if line.indent < indent || (line.indent == indent && !isYAMLSequenceItem(line.text)) {
+			break
+		}
+		if line.indent > indent {
+			return nil, yamlError(line.number, "unexpected indentation")
+		}
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if rest == "" {
+			parser.pos++
+			var item interface{}
+			if parser.pos < len(parser.lines) && parser.lines[parser.pos].indent > indent {
+				var err error
+				if item, err = parser.parseBlock(parser.lines[parser.pos].indent); err != nil {
+					return nil, err
+				}
+			}
+			items = append(items, item)
+			continue
+		}
+		// The content after "- " is parsed as a block that starts at its
+		// column, so that the following lines of a mapping item line up
+		// with its first key.
+		column := indent + len(line.text) - len(rest)
+		parser.lines[parser.pos] = yamlLine{number: line.number, indent: column, text: rest}
+		item, err := parser.parseBlock(column)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (parser *yamlParser) parseMapping(indent int) (interface{}, error) {
+	mapping := make(map[string]interface{})
+	for parser.pos < len(parser.lines) {
+		line := parser.lines[parser.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, yamlError(line.number, "unexpected indentation")
+		}
+		key, rest, ok, err := splitYAMLMappingEntry(line)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, yamlError(line.number, "expected \"key: value\", got %q", line.text)
+		}
+		if _, exists := mapping[key]; exists {
+			return nil, yamlError(line.number, "duplicate key %q", key)
+		}
+		parser.pos++
+		var value interface{}
+		switch {
+		case rest != "":
+			if value, err = parseYAMLInline(rest, line.number); err != nil {
+				return nil, err
+			}
+			if err := parser.checkNoContinuation(indent); err != nil {
+				return nil, err
+			}
+		case parser.pos < len(parser.lines) && parser.lines[parser.pos].indent > indent:
+			if value, err = parser.parseBlock(parser.lines[parser.pos].indent); err != nil {
+				return nil, err
+			}
+		case parser.pos < len(parser.lines) && parser.lines[parser.pos].indent == indent && isYAMLSequenceItem(parser.lines[parser.pos].text):
+			// A sequence may be indented like the key it belongs to.
+			if value, err = parser.parseSequence(indent); err != nil {
+				return nil, err
+			}
+		}
+		mapping[key] = value
+	}
+	return mapping, nil
+}
+
+// splitYAMLMappingEntry splits a "key: value" line. It reports false if the
+// line is not a mapping entry.
+func splitYAMLMappingEntry(line yamlLine) (key string, rest string, ok bool, err error) {
+	text := line.text
+	if text[0] == '"' || text[0] == '\'' {
+		key, after, err := parseYAMLQuoted(text, line.number)
+		if err != nil {
+			return "", "", false, err
+		}
+		after = strings.TrimLeft(after, " ")
+		if after == ":" || strings.HasPrefix(after, ": ") {
+			return key, strings.TrimSpace(after[1:]), true, nil
+		}
+		return "", "", false, nil
+	}
+	if text[0] == '?' && (len(text) == 1 || text[1] == ' ') {
+		return "", "", false, yamlError(line.number, "complex keys are not supported")
+	}
+	if strings.IndexByte("[{&*!|>@`", text[0]) >= 0 || isYAMLSequenceItem(text) {
+		return "", "", false, nil
+	}
+	index := strings.Index(text, ": ")
+	if index < 0 {
+		if !strings.HasSuffix(text, ":") {
+			return "", "", false, nil
+		}
+		index = len(text) - 1
+	}
+	return strings.TrimRight(text[:index], " "), strings.TrimSpace(text[index+1:]), true, nil
+}
+
+// parseYAMLInline parses the value on the rest of a line.
+func parseYAMLInline(text string, number int) (interface{}, error) {
+	switch text[0] {
+	case '[', '{':
+		flow := &yamlFlow{text: text, line: number}
+		value, err := flow.value()
+		if err != nil {
+			return nil, err
+		}
+		flow.skipSpaces()
+		if flow.pos < len(flow.text) {
+			return nil, yamlError(number, "unexpected %q after a flow collection", flow.text[flow.pos:])
+		}
+		return value, nil
+	case '"', '\'':
+		value, rest, err := parseYAMLQuoted(text, number)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, yamlError(number, "unexpected %q after a quoted scalar", strings.TrimSpace(rest))
+		}
+		return value, nil
+	case '&', '*':
+		return nil, yamlError(number, "anchors and aliases are not supported")
+	case '!':
+		return nil, yamlError(number, "tags are not supported")
+	case '|', '>':
+		return nil, yamlError(number, "block scalars are not supported")
+	case '@', '`':
+		return nil, yamlError(number, "a plain scalar cannot start with %q", text[0])
+	}
+	return resolveYAMLScalar(text), nil
+}
+
+// parseYAMLQuoted parses the quoted scalar at the start of text and returns it
+// with the text that follows it.
+func parseYAMLQuoted(text string, number int) (string, string, error) {
+	if text[0] == '\'' {
+		var value strings.Builder
+		for i := 1; i < len(text); i++ {
+			if text[i] != '\'' {
+				value.WriteByte(text[i])
+				continue
+			}
+			if i+1 < len(text) && text[i+1] == '\'' {
+				value.WriteByte('\'')
+				i++
+				continue
+			}
+			return value.String(), text[i+1:], nil
+		}
+		return "", "", yamlError(number, "unterminated single-quoted scalar")
+	}
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(text[:i+1])
+			if err != nil {
+				return "", "", yamlError(number, "invalid double-quoted scalar %s", text[:i+1])
+			}
+			return value, text[i+1:], nil
+		}
+	}
+	return "", "", yamlError(number, "unterminated double-quoted scalar")
+}
+
+var yamlNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+// resolveYAMLScalar resolves a plain scalar to null, a boolean, a number or a
+// string, following the core schema of YAML 1.2.
+func resolveYAMLScalar(text string) interface{} {
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	if yamlNumber.MatchString(text) {
+		return json.Number(text)
+	}
+	return text
+}
+
+// yamlFlow parses a flow collection that fits on one line.
+type yamlFlow struct {
+	text string
+	pos  int
+	line int
+}
+
+var errYAMLFlowEnd = errors.New("unterminated flow collection")
+
+func (flow *yamlFlow) skipSpaces() {
+	for flow.pos < len(flow.text) && flow.text[flow.pos] == ' ' {
+		flow.pos++
+	}
+}
+
+func (flow *yamlFlow) value() (interface{}, error) {
+	flow.skipSpaces()
+	if flow.pos >= len(flow.text) {
+		return nil, yamlError(flow.line, "%v", errYAMLFlowEnd)
+	}
+	switch flow.text[flow.pos] {
+	case '[':
+		flow.pos++
+		return flow.sequence()
+	case '{':
+		flow.pos++
+		return flow.mapping()
+	case '"', '\'':
+		value, rest, err := parseYAMLQuoted(flow.text[flow.pos:], flow.line)
+		if err != nil {
+			return nil, err
+		}
+		flow.pos = len(flow.text) - len(rest)
+		return value, nil
+	}
+	plain := flow.plain(",]}", false)
+	if plain == "" {
+		return nil, nil
+	}
+	return parseYAMLInline(plain, flow.line)
+}
+
+// plain reads a plain scalar up to one of the stop characters or a ':' that
+// separates it from a value. In a key, any ':' ends the scalar.
+func (flow *yamlFlow) plain(stop string, key bool) string {
+	start := flow.pos
+	for ; flow.pos < len(flow.text) && strings.IndexByte(stop, flow.text[flow.pos]) < 0; flow.pos++ {
+		if flow.text[flow.pos] == ':' && (key || flow.pos+1 == len(flow.text) || flow.text[flow.pos+1] == ' ') {
+			break
+		}
+	}
+	return strings.TrimSpace(flow.text[start:flow.pos])
+}
+
+// next skips to the character after the separator of a flow entry and reports
+// whether the collection ended.
+func (flow *yamlFlow) next(end byte) (bool, error) {
+	flow.skipSpaces()
+	if flow.pos >= len(flow.text) {
+		return false, yamlError(flow.line, "%v", errYAMLFlowEnd)
+	}
+	switch flow.text[flow.pos] {
+	case ',':
+		flow.pos++
+		return false, nil
+	case end:
+		flow.pos++
+		return true, nil
+	}
+	return false, yamlError(flow.line, "expected ',' or %q in a flow collection, got %q", end, flow.text[flow.pos:])
+}
+
+// atEnd consumes the end character if it is next.
+func (flow *yamlFlow) atEnd(end byte) bool {
+	flow.skipSpaces()
+	if flow.pos < len(flow.text) && flow.text[flow.pos] == end {
+		flow.pos++
+		return true
+	}
+	return false
+}
+
+func (flow *yamlFlow) sequence() (interface{}, error) {
+	items := []interface{}{}
+	for !flow.atEnd(']') {
+		item, err := flow.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if done, err := flow.next(']'); err != nil || done {
+			return items, err
+		}
+	}
+	return items, nil
+}
+
+func (flow *yamlFlow) mapping() (interface{}, error) {
+	mapping := make(map[string]interface{})
+	for !flow.atEnd('}') {
+		var key string
+		if flow.pos < len(flow.text) && (flow.text[flow.pos] == '"' || flow.text[flow.pos] == '\'') {
+			quoted, rest, err := parseYAMLQuoted(flow.text[flow.pos:], flow.line)
+			if err != nil {
+				return nil, err
+			}
+			key = quoted
+			flow.pos = len(flow.text) - len(rest)
+			flow.skipSpaces()
+		} else {
+			key = flow.plain(",}", true)
+		}
+		if flow.pos >= len(flow.text) || flow.text[flow.pos] != ':' {
+			return nil, yamlError(flow.line, "expected ':' after the key %q in a flow mapping", key)
+		}
+		flow.pos++
+		if _, exists := mapping[key]; exists {
+			return nil, yamlError(flow.line, "duplicate key %q", key)
+		}
+		value, err := flow.value()
+		if err != nil {
+			return nil, err
+		}
+		mapping[key] = value
+		if done, err := flow.next('}'); err != nil || done {
+			return mapping, err
+		}
+	}
+	return mapping, nil
+}
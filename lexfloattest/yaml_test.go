@@ -0,0 +1,76 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloattest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLToJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"empty", "# nothing\n", `{}`},
+		{"document start", "---\nname: x", `{"name":"x"}`},
+		{"scalars", "a: 10\nb: -1.5e3\nc: true\nd: ~\ne:\nf: 007\ng: 30m", `{"a":10,"b":-1.5e3,"c":true,"d":null,"e":null,"f":"007","g":"30m"}`},
+		{"quoted", `a: "10"` + "\nb: 'it''s # not a comment'\nc: \"tab\\tand \\\"quote\\\"\"", `{"a":"10","b":"it's # not a comment","c":"tab\tand \"quote\""}`},
+		{"quoted key", `"a b": 1`, `{"a b":1}`},
+		{"comments", "# head\na: x # trailing\nb: it's#not", `{"a":"x","b":"it's#not"}`},
+		{"plain with colon", "url: http://localhost:8090", `{"url":"http://localhost:8090"}`},
+		{"nested mapping", "a:\n  b:\n    c: 1\n  d: 2\ne: 3", `{"a":{"b":{"c":1},"d":2},"e":3}`},
+		{"indented sequence", "a:\n  - 1\n  - x", `{"a":[1,"x"]}`},
+		{"compact sequence", "a:\n- 1\n- 2\nb: 3", `{"a":[1,2],"b":3}`},
+		{"sequence of mappings", "a:\n  - b: 1\n    c: 2\n  - b: 3", `{"a":[{"b":1,"c":2},{"b":3}]}`},
+		{"nested sequences", "- - 1\n  - 2\n- -\n    - 3", `[[1,2],[[3]]]`},
+		{"empty item", "- \n- 1", `[null,1]`},
+		{"flow", "a: {b: [1, 'x, y', {c: d}], e: []}\nf: {}", `{"a":{"b":[1,"x, y",{"c":"d"}],"e":[]},"f":{}}`},
+		{"windows line endings", "a: 1\r\nb: 2\r\n", `{"a":1,"b":2}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := yamlToJSON([]byte(test.yaml))
+			if err != nil || string(got) != test.want {
+				t.Errorf("yamlToJSON(%q) = %s, %v, want %s", test.yaml, got, err, test.want)
+			}
+		})
+	}
+}
+
+func TestYAMLToJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"tab indentation", "a:\n\tb: 1", "line 2: tabs"},
+		{"bad indentation", "a:\n    b: 1\n  c: 2", "line 3: unexpected indentation"},
+		{"multi-line scalar", "a: one\n  two", "line 2: unexpected indentation"},
+		{"duplicate key", "a: 1\na: 2", `line 2: duplicate key "a"`},
+		{"duplicate flow key", "a: {b: 1, b: 2}", `line 1: duplicate key "b"`},
+		{"not a mapping entry", "a: 1\nb", `line 2: expected "key: value"`},
+		{"sequence in a mapping", "a: 1\n- 2", `line 2: expected "key: value"`},
+		{"anchor", "a: &x 1", "line 1: anchors and aliases"},
+		{"alias", "a: *x", "line 1: anchors and aliases"},
+		{"tag", "a: !!str 1", "line 1: tags"},
+		{"block scalar", "a: |\n  text", "line 1: block scalars"},
+		{"multiple documents", "a: 1\n---\nb: 2", "line 2: multiple documents"},
+		{"directive", "%YAML 1.2\n---\na: 1", "line 1: directives"},
+		{"unterminated quote", `a: "x`, "line 1: unterminated double-quoted"},
+		{"text after quote", `a: "x" y`, `line 1: unexpected "y"`},
+		{"unterminated flow", "a: [1, 2", "line 1: unterminated flow"},
+		{"flow without separator", "a: {b: 1 c: 2}", "line 1: expected ',' or '}'"},
+		{"flow key without value", "a: {b}", `line 1: expected ':' after the key "b"`},
+		{"complex key", "? a\n: b", "line 1: complex keys"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := yamlToJSON([]byte(test.yaml))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("yamlToJSON(%q) = %s, %v, want an error containing %q", test.yaml, got, err, test.want)
+			}
+		})
+	}
+}
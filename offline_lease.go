@@ -0,0 +1,149 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// offlineLeaseExpiryMargin is kept between an offline lease and the expiry of
+// the LexFloatServer license to allow for clock differences between the
+// floating client and the server.
+const offlineLeaseExpiryMargin = time.Minute
+
+// OfflineLease describes an offline lease granted by RequestOfflineFloatingLicenseUpTo.
+type OfflineLease struct {
+	// Requested is the lease duration passed by the caller.
+	Requested time.Duration
+
+	// Granted is the lease duration that was requested from the LexFloatServer
+	// after clamping.
+	Granted time.Duration
+
+	// Expiry is the lease expiry date reported by the library.
+	Expiry time.Time
+
+	// MaxOfflineLease is the maximum offline lease duration of the
+	// LexFloatServer. Zero if unknown.
+	MaxOfflineLease time.Duration
+
+	// HostLicenseExpiry is the expiry date of the LexFloatServer license. The
+	// zero time if it never expires or is unknown.
+	HostLicenseExpiry time.Time
+}
+
+// OfflineLeaseOptions configures RequestOfflineFloatingLicenseUpTo.
+type OfflineLeaseOptions struct {
+	// ProbeHostLicenseExpiry allows a short online lease to be requested to
+	// read the expiry date of the LexFloatServer license when it is unknown.
+	// The online lease takes a seat until it is dropped, so the probe fails
+	// with LF_E_LICENSE_LIMIT_REACHED when all seats are in use.
+	ProbeHostLicenseExpiry bool
+}
+
+// Clamped reports whether less than the requested lease duration was granted.
+func (offlineLease OfflineLease) Clamped() bool {
+	return offlineLease.Granted < offlineLease.Requested
+}
+
+// RequestOfflineFloatingLicenseUpTo sends the request to lease the license from
+// the LexFloatServer for offline usage for at most leaseDuration.
+//
+// The duration is clamped to the maximum offline lease duration in the host
+// configuration and to the expiry date of the LexFloatServer license, less a
+// margin of one minute. The library only knows the expiry date while it holds a
+// lease, so without a lease only the maximum offline lease duration is known.
+// If the server then rejects the request with
+// LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY, an error wrapping
+// ErrLeaseExceedsServerLicenseExpiry is returned, unless
+// ProbeHostLicenseExpiry is set: a short online lease is then requested to read
+// the expiry date and dropped, and the request is repeated once with the
+// clamped duration.
+//
+// Parameters:
+// - ctx: bounds the host configuration and lease requests
+// - leaseDuration: the longest acceptable lease duration, between one second
+// and math.MaxUint32 seconds
+// - options: see OfflineLeaseOptions
+//
+// Errors: ctx.Err(), an error if leaseDuration is out of range or the clamped
+// duration is shorter than one second, or see GetHostConfig, RequestFloatingLicense and
+// RequestOfflineFloatingLicense
+func RequestOfflineFloatingLicenseUpTo(ctx context.Context, leaseDuration time.Duration, options OfflineLeaseOptions) (OfflineLease, error) {
+	offlineLease := OfflineLease{Requested: leaseDuration}
+	if _, err := offlineLeaseSeconds(leaseDuration); err != nil {
+		return offlineLease, err
+	}
+	hostConfig, err := GetHostConfigContext(ctx)
+	if err != nil {
+		return offlineLease, opError("GetHostConfig", err)
+	}
+	offlineLease.MaxOfflineLease = hostConfig.MaxOfflineLease()
+	if hostLicenseExpiry, err := HostLicenseExpiryTime(); err == nil {
+		offlineLease.HostLicenseExpiry = hostLicenseExpiry
+	}
+	err = offlineLease.request(ctx)
+	if errors.Is(err, ErrLeaseExceedsServerLicenseExpiry) && offlineLease.HostLicenseExpiry.IsZero() {
+		if !options.ProbeHostLicenseExpiry {
+			return offlineLease, fmt.Errorf("lexfloatclient: offline lease of %v (maximum %v) exceeds the unknown expiry date of the server license: %w",
+				offlineLease.Granted, offlineLease.MaxOfflineLease, ErrLeaseExceedsServerLicenseExpiry)
+		}
+		hostLicenseExpiry, probeErr := probeHostLicenseExpiry(ctx)
+		if probeErr != nil {
+			return offlineLease, probeErr
+		}
+		if !hostLicenseExpiry.IsZero() {
+			offlineLease.HostLicenseExpiry = hostLicenseExpiry
+			err = offlineLease.request(ctx)
+		}
+	}
+	if err != nil {
+		return offlineLease, err
+	}
+	if leaseExpiry, err := FloatingClientLeaseExpiryTime(); err == nil {
+		offlineLease.Expiry = leaseExpiry
+	}
+	return offlineLease, nil
+}
+
+// request clamps the requested duration and requests the offline lease.
+func (offlineLease *OfflineLease) request(ctx context.Context) error {
+	offlineLease.Granted = 0
+	granted := offlineLease.Requested
+	if offlineLease.MaxOfflineLease > 0 && granted > offlineLease.MaxOfflineLease {
+		granted = offlineLease.MaxOfflineLease
+	}
+	if !offlineLease.HostLicenseExpiry.IsZero() {
+		untilExpiry := timeUntil(offlineLease.HostLicenseExpiry) - offlineLeaseExpiryMargin
+		if granted > untilExpiry {
+			granted = untilExpiry
+		}
+	}
+	granted = granted.Truncate(time.Second)
+	seconds, err := offlineLeaseSeconds(granted)
+	if err != nil {
+		return fmt.Errorf("lexfloatclient: no offline lease possible before the server license expires at %v: %w",
+			offlineLease.HostLicenseExpiry, ErrLeaseExceedsServerLicenseExpiry)
+	}
+	offlineLease.Granted = granted
+	return opError("RequestOfflineFloatingLicense", RequestOfflineFloatingLicenseContext(ctx, seconds))
+}
+
+// probeHostLicenseExpiry reads the expiry date of the LexFloatServer license
+// while holding a short online lease. See OfflineLeaseOptions.ProbeHostLicenseExpiry.
+func probeHostLicenseExpiry(ctx context.Context) (time.Time, error) {
+	if err := RequestFloatingLicenseContext(ctx); err != nil {
+		return time.Time{}, opError("RequestFloatingLicense", err)
+	}
+	hostLicenseExpiry, err := HostLicenseExpiryTime()
+	if dropErr := DropFloatingLicenseContext(ctx); dropErr != nil {
+		return time.Time{}, opError("DropFloatingLicense", dropErr)
+	}
+	if err != nil {
+		return time.Time{}, opError("GetHostLicenseExpiryDate", err)
+	}
+	return hostLicenseExpiry, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestRequestOfflineFloatingLicenseUpTo(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: 24 * time.Hour})
+	useBackend(t, server, server.NewBackend())
+	offlineLease, err := lexfloatclient.RequestOfflineFloatingLicenseUpTo(context.Background(), 48*time.Hour, lexfloatclient.OfflineLeaseOptions{})
+	if err != nil {
+		t.Fatalf("RequestOfflineFloatingLicenseUpTo: %v", err)
+	}
+	if offlineLease.Granted != 24*time.Hour || !offlineLease.Clamped() {
+		t.Errorf("Granted = %v, want 24h", offlineLease.Granted)
+	}
+	if want := server.Now().Add(24 * time.Hour); !offlineLease.Expiry.Equal(want) {
+		t.Errorf("Expiry = %v, want %v", offlineLease.Expiry, want)
+	}
+}
+
+func TestRequestOfflineFloatingLicenseUpToServerLicenseExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		probe       bool
+		wantErr     error
+		wantRequest int
+	}{
+		{name: "without probe", wantErr: lexfloatclient.ErrLeaseExceedsServerLicenseExpiry},
+		{name: "with probe", probe: true, wantRequest: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now().Truncate(time.Second)
+			server := lexfloattest.NewServer(lexfloattest.Config{
+				MaxOfflineLeaseDuration: 24 * time.Hour,
+				LicenseExpiry:           start.Add(2 * time.Hour),
+				Start:                   start,
+			})
+			useBackend(t, server, server.NewBackend())
+			offlineLease, err := lexfloatclient.RequestOfflineFloatingLicenseUpTo(context.Background(), 24*time.Hour, lexfloatclient.OfflineLeaseOptions{
+				ProbeHostLicenseExpiry: test.probe,
+			})
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("RequestOfflineFloatingLicenseUpTo = %v, want %v", err, test.wantErr)
+			}
+			if got := server.Calls(lexfloattest.OpRequestFloatingLicense); got != test.wantRequest {
+				t.Errorf("RequestFloatingLicense calls = %d, want %d", got, test.wantRequest)
+			}
+			if err != nil {
+				if got := server.ActiveLeases(); got != 0 {
+					t.Errorf("ActiveLeases = %d, want 0", got)
+				}
+				return
+			}
+			if offlineLease.Granted <= 0 || offlineLease.Granted > 2*time.Hour-time.Minute {
+				t.Errorf("Granted = %v, want at most 1h59m", offlineLease.Granted)
+			}
+			if got := server.ActiveLeases(); got != 1 {
+				t.Errorf("ActiveLeases = %d, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultServerLicenseMonitorInterval = time.Hour
+	durationDay                         = 24 * time.Hour
+)
+
+var defaultServerLicenseThresholds = []time.Duration{30 * durationDay, 7 * durationDay, durationDay, 0}
+
+// ServerLicenseWarning is passed to ServerLicenseMonitorOptions.OnWarning when
+// the expiry date of the LexFloatServer license is within a threshold.
+type ServerLicenseWarning struct {
+	// Expiry is the expiry date of the LexFloatServer license.
+	Expiry time.Time
+
+	// Remaining is the time left until the license expires. It is negative
+	// if the license has expired.
+	Remaining time.Duration
+
+	// Threshold is the threshold that has been reached. Zero means the
+	// license has expired.
+	Threshold time.Duration
+}
+
+// ServerLicenseMonitorOptions configures a ServerLicenseMonitor.
+type ServerLicenseMonitorOptions struct {
+	// Interval is the delay between reads of the expiry date. Default: 1 hour.
+	Interval time.Duration
+
+	// Thresholds are the times before the expiry date at which OnWarning is
+	// invoked. Negative thresholds are ignored. Default: 30 days, 7 days,
+	// 1 day and 0.
+	Thresholds []time.Duration
+
+	// OnWarning is invoked once per threshold and expiry date. When the
+	// monitor learns of an expiry date after some thresholds have passed,
+	// only the shortest passed threshold is reported.
+	OnWarning func(warning ServerLicenseWarning)
+
+	// OnProblem is invoked when reading the expiry date fails, or a renew
+	// status reports that the LexFloatServer license is not activated,
+	// suspended or expired, or its grace period is over. It may be nil.
+	OnProblem func(err error)
+}
+
+// ServerLicenseMonitor watches the expiry date of the LexFloatServer license
+// and warns before it expires. The expiry date is read while the floating
+// client holds a lease.
+type ServerLicenseMonitor struct {
+	options    ServerLicenseMonitorOptions
+	thresholds []time.Duration
+
+	mutex       sync.Mutex
+	expiry      time.Time
+	known       bool
+	warned      map[time.Duration]bool
+	cancel      context.CancelFunc
+	done        chan struct{}
+	unsubscribe func()
+}
+
+// NewServerLicenseMonitor returns a ServerLicenseMonitor that is not started.
+func NewServerLicenseMonitor(options ServerLicenseMonitorOptions) *ServerLicenseMonitor {
+	thresholds := options.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = defaultServerLicenseThresholds
+	}
+	thresholdSet := make(map[time.Duration]bool)
+	for _, threshold := range thresholds {
+		if threshold >= 0 {
+			thresholdSet[threshold] = true
+		}
+	}
+	sortedThresholds := make([]time.Duration, 0, len(thresholdSet))
+	for threshold := range thresholdSet {
+		sortedThresholds = append(sortedThresholds, threshold)
+	}
+	sort.Slice(sortedThresholds, func(i, j int) bool { return sortedThresholds[i] < sortedThresholds[j] })
+	return &ServerLicenseMonitor{
+		options:    options,
+		thresholds: sortedThresholds,
+		warned:     make(map[time.Duration]bool),
+	}
+}
+
+// Start reads the expiry date and keeps reading it at the configured interval
+// until ctx is done or Stop is called. Starting a started monitor does nothing;
+// a monitor can be started again once ctx is done or after Stop.
+func (monitor *ServerLicenseMonitor) Start(ctx context.Context) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+	if monitor.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	monitor.cancel = cancel
+	monitor.done = make(chan struct{})
+	monitor.unsubscribe = Subscribe(monitor.renewed)
+	go monitor.poll(ctx, monitor.done)
+}
+
+// Stop stops the monitor and waits for a pending read to finish.
+func (monitor *ServerLicenseMonitor) Stop() {
+	monitor.mutex.Lock()
+	cancel, done, unsubscribe := monitor.cancel, monitor.done, monitor.unsubscribe
+	monitor.cancel, monitor.done, monitor.unsubscribe = nil, nil, nil
+	monitor.mutex.Unlock()
+	if cancel == nil {
+		return
+	}
+	unsubscribe()
+	cancel()
+	<-done
+}
+
+func (monitor *ServerLicenseMonitor) poll(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	defer monitor.finish(done)
+	interval := monitor.options.Interval
+	if interval <= 0 {
+		interval = defaultServerLicenseMonitorInterval
+	}
+	for {
+		monitor.Check()
+		if sleepContext(ctx, interval) != nil {
+			return
+		}
+	}
+}
+
+// finish resets the monitor when poll returns because the context of Start is
+// done, so that the monitor can be started again. It does nothing if Stop
+// has already reset the monitor.
+func (monitor *ServerLicenseMonitor) finish(done chan struct{}) {
+	monitor.mutex.Lock()
+	if monitor.done != done {
+		monitor.mutex.Unlock()
+		return
+	}
+	cancel, unsubscribe := monitor.cancel, monitor.unsubscribe
+	monitor.cancel, monitor.done, monitor.unsubscribe = nil, nil, nil
+	monitor.mutex.Unlock()
+	unsubscribe()
+	cancel()
+}
+
+// renewed is subscribed to the renew statuses.
+func (monitor *ServerLicenseMonitor) renewed(status int) {
+	if leaseEventKindOf(StatusCode(status)) == LeaseEventServerLicenseProblem && monitor.options.OnProblem != nil {
+		monitor.options.OnProblem(StatusCode(status))
+	}
+}
+
+// Check reads the expiry date now and invokes OnWarning for the thresholds
+// that have been reached. Without a lease the last known expiry date is kept.
+func (monitor *ServerLicenseMonitor) Check() {
+	expiry, err := HostLicenseExpiryTime()
+	if errors.Is(err, ErrNoLicense) {
+		return
+	}
+	if err != nil {
+		if monitor.options.OnProblem != nil {
+			monitor.options.OnProblem(opError("GetHostLicenseExpiryDate", err))
+		}
+		return
+	}
+	now := currentTime()
+	var warning *ServerLicenseWarning
+	monitor.mutex.Lock()
+	if !monitor.known || !expiry.Equal(monitor.expiry) {
+		monitor.warned = make(map[time.Duration]bool)
+	}
+	monitor.expiry, monitor.known = expiry, true
+	if !expiry.IsZero() {
+		remaining := expiry.Sub(now)
+		// Thresholds are sorted ascending, so the first reached threshold is
+		// the shortest one; longer reached thresholds are only marked.
+		reported := false
+		for _, threshold := range monitor.thresholds {
+			if remaining > threshold {
+				continue
+			}
+			if !reported && !monitor.warned[threshold] {
+				warning = &ServerLicenseWarning{Expiry: expiry, Remaining: remaining, Threshold: threshold}
+			}
+			reported = true
+			monitor.warned[threshold] = true
+		}
+	}
+	monitor.mutex.Unlock()
+	if warning != nil && monitor.options.OnWarning != nil {
+		monitor.options.OnWarning(*warning)
+	}
+}
+
+// Expiry returns the last known expiry date of the LexFloatServer license,
+// which is the zero time if it never expires.
+//
+// Returns: the expiry date, and false if it has not been read yet
+func (monitor *ServerLicenseMonitor) Expiry() (time.Time, bool) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+	return monitor.expiry, monitor.known
+}
+
+// DaysRemaining returns the number of whole days left until the LexFloatServer
+// license expires, e.g. for dashboards. It is negative once the license has
+// expired.
+//
+// Returns: the days remaining, and false if the expiry date has not been read
+// yet or the license never expires
+func (monitor *ServerLicenseMonitor) DaysRemaining() (int, bool) {
+	expiry, known := monitor.Expiry()
+	if !known || expiry.IsZero() {
+		return 0, false
+	}
+	remaining := timeUntil(expiry)
+	days := int(remaining / durationDay)
+	if remaining < 0 && remaining%durationDay != 0 {
+		days--
+	}
+	return days, true
+}
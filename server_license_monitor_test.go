@@ -0,0 +1,213 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+// expiryBackend counts the reads of the server license expiry date and makes
+// them fail with status if it is set.
+type expiryBackend struct {
+	*lexfloattest.Backend
+	reads  *int32
+	status *int32
+}
+
+func (backend expiryBackend) GetHostLicenseExpiryDate(expiryDate *uint) int {
+	atomic.AddInt32(backend.reads, 1)
+	if status := atomic.LoadInt32(backend.status); status != 0 {
+		return int(status)
+	}
+	return backend.Backend.GetHostLicenseExpiryDate(expiryDate)
+}
+
+// monitorRecorder records the warnings and problems of a monitor.
+type monitorRecorder struct {
+	mutex    sync.Mutex
+	warnings []lexfloatclient.ServerLicenseWarning
+	problems []error
+}
+
+func (recorder *monitorRecorder) options() lexfloatclient.ServerLicenseMonitorOptions {
+	return lexfloatclient.ServerLicenseMonitorOptions{
+		OnWarning: func(warning lexfloatclient.ServerLicenseWarning) {
+			recorder.mutex.Lock()
+			defer recorder.mutex.Unlock()
+			recorder.warnings = append(recorder.warnings, warning)
+		},
+		OnProblem: func(err error) {
+			recorder.mutex.Lock()
+			defer recorder.mutex.Unlock()
+			recorder.problems = append(recorder.problems, err)
+		},
+	}
+}
+
+// thresholds returns the thresholds of the recorded warnings.
+func (recorder *monitorRecorder) thresholds() []time.Duration {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	thresholds := make([]time.Duration, 0, len(recorder.warnings))
+	for _, warning := range recorder.warnings {
+		thresholds = append(thresholds, warning.Threshold)
+	}
+	return thresholds
+}
+
+func (recorder *monitorRecorder) recordedProblems() []error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return append([]error(nil), recorder.problems...)
+}
+
+func equalDurations(a, b []time.Duration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestServerLicenseMonitorThresholds(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	server := leasedServer(t, lexfloattest.Config{Start: start, LicenseExpiry: start.Add(10 * 24 * time.Hour)})
+	recorder := &monitorRecorder{}
+	monitor := lexfloatclient.NewServerLicenseMonitor(recorder.options())
+	day := 24 * time.Hour
+	steps := []struct {
+		name    string
+		advance time.Duration
+		expiry  time.Time
+		want    []time.Duration
+	}{
+		// Only the 30 day threshold has been reached, and only once.
+		{name: "first read", want: []time.Duration{30 * day}},
+		{name: "second read", want: []time.Duration{30 * day}},
+		{name: "7 days", advance: 4 * day, want: []time.Duration{30 * day, 7 * day}},
+		{name: "same threshold", advance: time.Hour, want: []time.Duration{30 * day, 7 * day}},
+		// A new expiry date reports the shortest passed threshold again.
+		{name: "new expiry date", expiry: start.Add(4*day + 13*time.Hour), want: []time.Duration{30 * day, 7 * day, day}},
+		{name: "expired", expiry: start.Add(4*day + time.Hour), want: []time.Duration{30 * day, 7 * day, day, 0}},
+	}
+	for _, step := range steps {
+		if step.advance > 0 {
+			server.Advance(step.advance)
+		}
+		if !step.expiry.IsZero() {
+			server.SetLicenseExpiry(step.expiry)
+		}
+		monitor.Check()
+		if got := recorder.thresholds(); !equalDurations(got, step.want) {
+			t.Fatalf("%s: warned at %v, want %v", step.name, got, step.want)
+		}
+	}
+	warnings := recorder.warnings
+	if last := warnings[len(warnings)-1]; last.Remaining != 0 || !last.Expiry.Equal(start.Add(4*day+time.Hour)) {
+		t.Errorf("last warning = %+v, want the expiry date with nothing remaining", last)
+	}
+	if problems := recorder.recordedProblems(); len(problems) != 0 {
+		t.Errorf("problems = %v, want none", problems)
+	}
+}
+
+func TestServerLicenseMonitorDaysRemaining(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	server := leasedServer(t, lexfloattest.Config{Start: start})
+	monitor := lexfloatclient.NewServerLicenseMonitor(lexfloatclient.ServerLicenseMonitorOptions{})
+	if days, ok := monitor.DaysRemaining(); ok {
+		t.Errorf("before the first read: DaysRemaining = %d, true, want false", days)
+	}
+	monitor.Check()
+	if days, ok := monitor.DaysRemaining(); ok {
+		t.Errorf("never expires: DaysRemaining = %d, true, want false", days)
+	}
+	if _, known := monitor.Expiry(); !known {
+		t.Error("never expires: Expiry is not known")
+	}
+	tests := []struct {
+		remaining time.Duration
+		want      int
+	}{
+		{remaining: 48 * time.Hour, want: 2},
+		{remaining: 47 * time.Hour, want: 1},
+		{remaining: time.Hour, want: 0},
+		{remaining: 0, want: 0},
+		{remaining: -time.Hour, want: -1},
+		{remaining: -24 * time.Hour, want: -1},
+		{remaining: -25 * time.Hour, want: -2},
+	}
+	for _, test := range tests {
+		server.SetLicenseExpiry(start.Add(test.remaining))
+		monitor.Check()
+		if days, ok := monitor.DaysRemaining(); !ok || days != test.want {
+			t.Errorf("remaining %v: DaysRemaining = %d, %t, want %d", test.remaining, days, ok, test.want)
+		}
+	}
+}
+
+func TestServerLicenseMonitorProblems(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	var reads, status int32
+	useBackend(t, server, expiryBackend{Backend: server.NewBackend(), reads: &reads, status: &status})
+	recorder := &monitorRecorder{}
+	monitor := lexfloatclient.NewServerLicenseMonitor(recorder.options())
+	// Without a lease there is nothing to read and nothing to report.
+	monitor.Check()
+	if problems := recorder.recordedProblems(); len(problems) != 0 {
+		t.Fatalf("without a lease: problems = %v, want none", problems)
+	}
+	if err := lexfloatclient.RequestFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("RequestFloatingLicenseContext: %v", err)
+	}
+	atomic.StoreInt32(&status, int32(lexfloatclient.LF_E_CLIENT))
+	monitor.Check()
+	atomic.StoreInt32(&status, 0)
+	monitor.Start(context.Background())
+	defer monitor.Stop()
+	server.AddFault(lexfloattest.Fault{Op: lexfloattest.OpRenew, Status: lexfloatclient.LF_E_SERVER_LICENSE_SUSPENDED})
+	server.Advance(15 * time.Minute)
+	lexfloatclient.WaitForCallbacks()
+	problems := recorder.recordedProblems()
+	if len(problems) != 2 {
+		t.Fatalf("problems = %v, want 2", problems)
+	}
+	if !errors.Is(problems[0], lexfloatclient.ErrClient) {
+		t.Errorf("read problem = %v, want ErrClient", problems[0])
+	}
+	if !errors.Is(problems[1], lexfloatclient.ErrServerLicenseSuspended) {
+		t.Errorf("renew problem = %v, want ErrServerLicenseSuspended", problems[1])
+	}
+}
+
+func TestServerLicenseMonitorRestart(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	var reads, status int32
+	useBackend(t, server, expiryBackend{Backend: server.NewBackend(), reads: &reads, status: &status})
+	monitor := lexfloatclient.NewServerLicenseMonitor(lexfloatclient.ServerLicenseMonitorOptions{})
+	defer monitor.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	monitor.Start(ctx)
+	waitFor(t, "the first read", func() bool { return atomic.LoadInt32(&reads) == 1 })
+	cancel()
+	// Once the context is done, Start starts the monitor again.
+	waitFor(t, "the read after the restart", func() bool {
+		monitor.Start(context.Background())
+		return atomic.LoadInt32(&reads) == 2
+	})
+	monitor.Stop()
+	monitor.Start(context.Background())
+	waitFor(t, "the read after Stop", func() bool { return atomic.LoadInt32(&reads) == 3 })
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const defaultShutdownTimeout = 10 * time.Second
+
+// exit is invoked by HandleShutdown to exit the process. It is replaced in tests.
+var exit = os.Exit
+
+// ShutdownOptions configures Shutdown, HandleShutdown and Run.
+type ShutdownOptions struct {
+	// Timeout bounds the time spent dropping the lease and running the hooks.
+	// Default: 10 seconds.
+	Timeout time.Duration
+
+	// Signals are the signals handled by HandleShutdown. Default: SIGINT,
+	// SIGTERM and SIGHUP.
+	Signals []os.Signal
+
+	// DropOffline makes Shutdown drop an offline lease too. By default an
+	// offline lease is kept, so the application can run offline on the next
+	// start until the lease expires.
+	DropOffline bool
+
+	// Hooks are invoked in order after the lease has been dropped. The context
+	// expires when Timeout has elapsed.
+	Hooks []func(ctx context.Context)
+
+	// OnError is invoked by HandleShutdown with the error returned by Shutdown
+	// before the process exits. It may be nil.
+	OnError func(err error)
+}
+
+func (options ShutdownOptions) timeout() time.Duration {
+	if options.Timeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return options.Timeout
+}
+
+func (options ShutdownOptions) signals() []os.Signal {
+	if len(options.Signals) == 0 {
+		return []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}
+	}
+	return options.Signals
+}
+
+// Shutdown drops the online lease, if one is held, and then invokes the hooks.
+// Call it before os.Exit or log.Fatal, which skip deferred calls.
+//
+// Shutdown does not always drop the lease: an offline lease is kept unless
+// DropOffline is set, so that an application that requested it with EnsureLease
+// or RequestOfflineFloatingLicenseUpTo can run offline after a restart until
+// the lease expires. Set DropOffline to drop every lease.
+//
+// Errors: context.DeadlineExceeded, see GetFloatingLicenseMode or see DropFloatingLicense
+func Shutdown(options ShutdownOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout())
+	defer cancel()
+	var err error
+	if HasFloatingLicense() == LF_OK {
+		err = dropOnShutdown(ctx, options)
+	}
+	for _, hook := range options.Hooks {
+		hook(ctx)
+	}
+	return err
+}
+
+func dropOnShutdown(ctx context.Context, options ShutdownOptions) error {
+	if !options.DropOffline {
+		mode, err := FloatingLicenseMode()
+		if err != nil {
+			return opError("GetFloatingLicenseMode", err)
+		}
+		if mode != LeaseModeOnline {
+			return nil
+		}
+	}
+	return opError("DropFloatingLicense", DropFloatingLicenseContext(ctx))
+}
+
+// HandleShutdown installs a handler for the shutdown signals. On the first
+// signal it calls Shutdown and exits the process with status 128 plus the
+// signal number, or 1 for signals without a number.
+//
+// Returns: a function that removes the handler
+func HandleShutdown(options ShutdownOptions) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, options.signals()...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case received := <-signals:
+			if err := Shutdown(options); err != nil && options.OnError != nil {
+				options.OnError(err)
+			}
+			code := 1
+			if number, ok := received.(syscall.Signal); ok {
+				code = 128 + int(number)
+			}
+			exit(code)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// Run invokes run and then calls Shutdown, also when run panics, in which case
+// the panic is propagated after the lease has been dropped.
+//
+// Returns: the error returned by run, otherwise the error returned by Shutdown
+func Run(ctx context.Context, options ShutdownOptions, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		shutdownErr := Shutdown(options)
+		if err == nil {
+			err = shutdownErr
+		}
+	}()
+	return run(ctx)
+}
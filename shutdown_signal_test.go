@@ -0,0 +1,75 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+// +build linux darwin
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestHandleShutdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		fault   int
+		wantErr error
+	}{
+		{name: "dropped"},
+		{name: "drop failed", fault: lexfloatclient.LF_E_INET, wantErr: lexfloatclient.ErrInet},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := leasedServer(t, lexfloattest.Config{})
+			if test.fault != 0 {
+				server.AddFault(lexfloattest.Fault{Op: lexfloattest.OpDropFloatingLicense, Status: test.fault})
+			}
+			codes := make(chan int, 1)
+			defer lexfloatclient.SetExit(lexfloatclient.SetExit(func(code int) { codes <- code }))
+			var (
+				hookLeases int
+				errs       []error
+			)
+			stop := lexfloatclient.HandleShutdown(lexfloatclient.ShutdownOptions{
+				Signals: []os.Signal{syscall.SIGUSR1},
+				Hooks: []func(ctx context.Context){
+					func(ctx context.Context) { hookLeases = server.ActiveLeases() },
+				},
+				OnError: func(err error) { errs = append(errs, err) },
+			})
+			defer stop()
+			if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+				t.Fatalf("Kill: %v", err)
+			}
+			select {
+			case code := <-codes:
+				if want := 128 + int(syscall.SIGUSR1); code != want {
+					t.Errorf("exit code = %d, want %d", code, want)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("HandleShutdown did not exit after the signal")
+			}
+			wantLeases := 0
+			if test.wantErr != nil {
+				wantLeases = 1
+			}
+			// The hooks run after the lease has been dropped.
+			if hookLeases != wantLeases {
+				t.Errorf("active leases in the hook = %d, want %d", hookLeases, wantLeases)
+			}
+			if test.wantErr == nil && len(errs) != 0 {
+				t.Errorf("OnError called with %v, want no error", errs)
+			}
+			if test.wantErr != nil && (len(errs) != 1 || !errors.Is(errs[0], test.wantErr)) {
+				t.Errorf("OnError called with %v, want %v", errs, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		name        string
+		offline     bool
+		dropOffline bool
+		wantLeases  int
+	}{
+		{name: "online", wantLeases: 0},
+		{name: "offline", offline: true, wantLeases: 1},
+		{name: "offline with DropOffline", offline: true, dropOffline: true, wantLeases: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := lexfloattest.NewServer(lexfloattest.Config{MaxOfflineLeaseDuration: time.Hour})
+			useBackend(t, server, server.NewBackend())
+			var err error
+			if test.offline {
+				err = lexfloatclient.RequestOfflineFloatingLicenseFor(time.Hour)
+			} else {
+				err = lexfloatclient.RequestFloatingLicenseContext(context.Background())
+			}
+			if err != nil {
+				t.Fatalf("request lease: %v", err)
+			}
+			hooks := 0
+			err = lexfloatclient.Shutdown(lexfloatclient.ShutdownOptions{
+				DropOffline: test.dropOffline,
+				Hooks: []func(ctx context.Context){
+					func(ctx context.Context) { hooks++ },
+				},
+			})
+			if err != nil {
+				t.Fatalf("Shutdown: %v", err)
+			}
+			if got := server.ActiveLeases(); got != test.wantLeases {
+				t.Errorf("ActiveLeases = %d, want %d", got, test.wantLeases)
+			}
+			if hooks != 1 {
+				t.Errorf("hooks invoked %d times, want 1", hooks)
+			}
+		})
+	}
+}
+
+func TestRunDropsLeaseOnPanic(t *testing.T) {
+	server := lexfloattest.NewServer(lexfloattest.Config{})
+	useBackend(t, server, server.NewBackend())
+	defer func() {
+		if recover() == nil {
+			t.Error("Run did not propagate the panic")
+		}
+		if got := server.ActiveLeases(); got != 0 {
+			t.Errorf("ActiveLeases = %d, want 0", got)
+		}
+	}()
+	lexfloatclient.Run(context.Background(), lexfloatclient.ShutdownOptions{}, func(ctx context.Context) error {
+		if err := lexfloatclient.RequestFloatingLicenseContext(ctx); err != nil {
+			t.Fatalf("RequestFloatingLicenseContext: %v", err)
+		}
+		panic("run")
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "fmt"
+
+// StatusCode is a status code returned by the LexFloatClient library.
+//
+// StatusCode implements the error interface so that status codes can be
+// returned, wrapped and inspected with errors.Is and errors.As:
+//
+//	if errors.Is(err, lexfloatclient.ErrLicenseLimitReached) { ... }
+//
+//	var code lexfloatclient.StatusCode
+//	if errors.As(err, &code) { ... }
+type StatusCode int
+
+// Error values for every failure code of the LexFloatClient library.
+const (
+	ErrFail                                      = StatusCode(LF_FAIL)
+	ErrProductId                                 = StatusCode(LF_E_PRODUCT_ID)
+	ErrCallback                                  = StatusCode(LF_E_CALLBACK)
+	ErrHostUrl                                   = StatusCode(LF_E_HOST_URL)
+	ErrTime                                      = StatusCode(LF_E_TIME)
+	ErrInet                                      = StatusCode(LF_E_INET)
+	ErrNoLicense                                 = StatusCode(LF_E_NO_LICENSE)
+	ErrLicenseExists                             = StatusCode(LF_E_LICENSE_EXISTS)
+	ErrLicenseNotFound                           = StatusCode(LF_E_LICENSE_NOT_FOUND)
+	ErrLicenseExpiredInet                        = StatusCode(LF_E_LICENSE_EXPIRED_INET)
+	ErrLicenseLimitReached                       = StatusCode(LF_E_LICENSE_LIMIT_REACHED)
+	ErrBufferSize                                = StatusCode(LF_E_BUFFER_SIZE)
+	ErrMetadataKeyNotFound                       = StatusCode(LF_E_METADATA_KEY_NOT_FOUND)
+	ErrMetadataKeyLength                         = StatusCode(LF_E_METADATA_KEY_LENGTH)
+	ErrMetadataValueLength                       = StatusCode(LF_E_METADATA_VALUE_LENGTH)
+	ErrFloatingClientMetadataLimit               = StatusCode(LF_E_FLOATING_CLIENT_METADATA_LIMIT)
+	ErrMeterAttributeNotFound                    = StatusCode(LF_E_METER_ATTRIBUTE_NOT_FOUND)
+	ErrMeterAttributeUsesLimitReached            = StatusCode(LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED)
+	ErrProductVersionNotLinked                   = StatusCode(LF_E_PRODUCT_VERSION_NOT_LINKED)
+	ErrFeatureFlagNotFound                       = StatusCode(LF_E_FEATURE_FLAG_NOT_FOUND)
+	ErrSystemPermission                          = StatusCode(LF_E_SYSTEM_PERMISSION)
+	ErrIp                                        = StatusCode(LF_E_IP)
+	ErrInvalidPermissionFlag                     = StatusCode(LF_E_INVALID_PERMISSION_FLAG)
+	ErrOfflineFloatingLicenseNotAllowed          = StatusCode(LF_E_OFFLINE_FLOATING_LICENSE_NOT_ALLOWED)
+	ErrMaxOfflineLeaseDurationExceeded           = StatusCode(LF_E_MAX_OFFLINE_LEASE_DURATION_EXCEEDED)
+	ErrAllowedOfflineFloatingClientsLimitReached = StatusCode(LF_E_ALLOWED_OFFLINE_FLOATING_CLIENTS_LIMIT_REACHED)
+	ErrWmic                                      = StatusCode(LF_E_WMIC)
+	ErrMachineFingerprint                        = StatusCode(LF_E_MACHINE_FINGERPRINT)
+	ErrProxyNotTrusted                           = StatusCode(LF_E_PROXY_NOT_TRUSTED)
+	ErrEntitlementSetNotLinked                   = StatusCode(LF_E_ENTITLEMENT_SET_NOT_LINKED)
+	ErrFeatureEntitlementNotFound                = StatusCode(LF_E_FEATURE_ENTITLEMENT_NOT_FOUND)
+	ErrClient                                    = StatusCode(LF_E_CLIENT)
+	ErrServer                                    = StatusCode(LF_E_SERVER)
+	ErrServerTimeModified                        = StatusCode(LF_E_SERVER_TIME_MODIFIED)
+	ErrServerLicenseNotActivated                 = StatusCode(LF_E_SERVER_LICENSE_NOT_ACTIVATED)
+	ErrServerLicenseExpired                      = StatusCode(LF_E_SERVER_LICENSE_EXPIRED)
+	ErrServerLicenseSuspended                    = StatusCode(LF_E_SERVER_LICENSE_SUSPENDED)
+	ErrServerLicenseGracePeriodOver              = StatusCode(LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER)
+	ErrLeaseExceedsServerLicenseExpiry           = StatusCode(LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY)
+)
+
+// Error returns the error message for the status code, e.g.
+// "LF_E_INET (44): Failed to connect to the server due to network error."
+func (s StatusCode) Error() string {
+	return fmt.Sprintf("%s (%d): %s", s.String(), int(s), s.Message())
+}
+
+// StatusError converts a status code returned by one of the int based
+// functions of this package into an error.
+//
+// Parameters:
+// - status: the status code
+//
+// Returns: nil for LF_OK, otherwise the StatusCode of the status
+func StatusError(status int) error {
+	if status == LF_OK {
+		return nil
+	}
+	return StatusCode(status)
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Category classifies status codes by their cause.
+type Category int
+
+const (
+	// CategoryNone is the category of LF_OK.
+	CategoryNone Category = iota
+
+	// CategoryUnknown is the category of LF_FAIL and of unknown status codes.
+	CategoryUnknown
+
+	// CategoryNetwork covers failures to reach or talk to the LexFloatServer.
+	CategoryNetwork
+
+	// CategoryServerLicense covers problems with the license of the LexFloatServer itself.
+	CategoryServerLicense
+
+	// CategoryClientConfig covers invalid configuration or environment of the floating client.
+	CategoryClientConfig
+
+	// CategoryQuota covers limits imposed by the license or the server configuration.
+	CategoryQuota
+
+	// CategoryTime covers tampered or incorrect system time on the client or the server.
+	CategoryTime
+
+	// CategoryLease covers the lease state of the floating client.
+	CategoryLease
+
+	// CategoryNotFound covers lookups of metadata, meter attributes and entitlements that do not exist.
+	CategoryNotFound
+)
+
+var categoryNames = map[Category]string{
+	CategoryNone:          "none",
+	CategoryUnknown:       "unknown",
+	CategoryNetwork:       "network",
+	CategoryServerLicense: "server-license",
+	CategoryClientConfig:  "client-config",
+	CategoryQuota:         "quota",
+	CategoryTime:          "time",
+	CategoryLease:         "lease",
+	CategoryNotFound:      "not-found",
+}
+
+// String returns the name of the category.
+func (c Category) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return "Category(" + strconv.Itoa(int(c)) + ")"
+}
+
+type statusCodeInfo struct {
+	name      string
+	message   string
+	category  Category
+	retryable bool
+}
+
+// statusCodeInfos holds the messages of LexFloatStatusCodes.h.
+var statusCodeInfos = map[StatusCode]statusCodeInfo{
+	StatusCode(LF_OK): {"LF_OK", "Success code.", CategoryNone, false},
+	ErrFail:           {"LF_FAIL", "Failure code.", CategoryUnknown, false},
+	ErrProductId:      {"LF_E_PRODUCT_ID", "The product id is incorrect.", CategoryClientConfig, false},
+	ErrCallback:       {"LF_E_CALLBACK", "Invalid or missing callback function.", CategoryClientConfig, false},
+	ErrHostUrl:        {"LF_E_HOST_URL", "Missing or invalid server url.", CategoryClientConfig, false},
+	ErrTime:           {"LF_E_TIME", "Ensure system date and time settings are correct.", CategoryTime, false},
+	ErrInet:           {"LF_E_INET", "Failed to connect to the server due to network error.", CategoryNetwork, true},
+	ErrNoLicense:      {"LF_E_NO_LICENSE", "License has not been leased yet.", CategoryLease, false},
+	ErrLicenseExists:  {"LF_E_LICENSE_EXISTS", "License has already been leased.", CategoryLease, false},
+	ErrLicenseNotFound: {"LF_E_LICENSE_NOT_FOUND", "License does not exist on server or has already expired. " +
+		"This happens when the request to refresh the license is delayed.", CategoryLease, true},
+	ErrLicenseExpiredInet: {"LF_E_LICENSE_EXPIRED_INET", "License lease has expired due to network error. " +
+		"This happens when the request to refresh the license fails due to network error.", CategoryNetwork, true},
+	ErrLicenseLimitReached:            {"LF_E_LICENSE_LIMIT_REACHED", "The server has reached it's allowed limit of floating licenses.", CategoryQuota, true},
+	ErrBufferSize:                     {"LF_E_BUFFER_SIZE", "The buffer size was smaller than required.", CategoryClientConfig, false},
+	ErrMetadataKeyNotFound:            {"LF_E_METADATA_KEY_NOT_FOUND", "The metadata key does not exist.", CategoryNotFound, false},
+	ErrMetadataKeyLength:              {"LF_E_METADATA_KEY_LENGTH", "Metadata key length is more than 256 characters.", CategoryClientConfig, false},
+	ErrMetadataValueLength:            {"LF_E_METADATA_VALUE_LENGTH", "Metadata value length is more than 4096 characters.", CategoryClientConfig, false},
+	ErrFloatingClientMetadataLimit:    {"LF_E_FLOATING_CLIENT_METADATA_LIMIT", "The floating client has reached it's metadata fields limit.", CategoryQuota, false},
+	ErrMeterAttributeNotFound:         {"LF_E_METER_ATTRIBUTE_NOT_FOUND", "The meter attribute does not exist.", CategoryNotFound, false},
+	ErrMeterAttributeUsesLimitReached: {"LF_E_METER_ATTRIBUTE_USES_LIMIT_REACHED", "The meter attribute has reached it's usage limit.", CategoryQuota, false},
+	ErrProductVersionNotLinked:        {"LF_E_PRODUCT_VERSION_NOT_LINKED", "No product version is linked with the license.", CategoryNotFound, false},
+	ErrFeatureFlagNotFound:            {"LF_E_FEATURE_FLAG_NOT_FOUND", "The product version feature flag does not exist.", CategoryNotFound, false},
+	ErrSystemPermission:               {"LF_E_SYSTEM_PERMISSION", "Insufficient system permissions.", CategoryClientConfig, false},
+	ErrIp:                             {"LF_E_IP", "IP address is not allowed.", CategoryNetwork, false},
+	ErrInvalidPermissionFlag:          {"LF_E_INVALID_PERMISSION_FLAG", "Invalid permission flag.", CategoryClientConfig, false},
+	ErrOfflineFloatingLicenseNotAllowed: {"LF_E_OFFLINE_FLOATING_LICENSE_NOT_ALLOWED",
+		"Offline floating license is not allowed for per-instance leasing strategy.", CategoryQuota, false},
+	ErrMaxOfflineLeaseDurationExceeded: {"LF_E_MAX_OFFLINE_LEASE_DURATION_EXCEEDED", "Maximum offline lease duration exceeded.", CategoryQuota, false},
+	ErrAllowedOfflineFloatingClientsLimitReached: {"LF_E_ALLOWED_OFFLINE_FLOATING_CLIENTS_LIMIT_REACHED",
+		"Allowed offline floating clients limit reached.", CategoryQuota, true},
+	ErrWmic: {"LF_E_WMIC", "Fingerprint couldn't be generated because Windows Management Instrumentation (WMI) " +
+		"service has been disabled. This error is specific to Windows only.", CategoryClientConfig, false},
+	ErrMachineFingerprint:         {"LF_E_MACHINE_FINGERPRINT", "Machine fingerprint has changed since activation.", CategoryClientConfig, false},
+	ErrProxyNotTrusted:            {"LF_E_PROXY_NOT_TRUSTED", "Request blocked due to untrusted proxy.", CategoryNetwork, false},
+	ErrEntitlementSetNotLinked:    {"LF_E_ENTITLEMENT_SET_NOT_LINKED", "No entitlement set is linked to the license.", CategoryNotFound, false},
+	ErrFeatureEntitlementNotFound: {"LF_E_FEATURE_ENTITLEMENT_NOT_FOUND", "The feature entitlement does not exist.", CategoryNotFound, false},
+	ErrClient:                     {"LF_E_CLIENT", "Client error.", CategoryNetwork, false},
+	ErrServer:                     {"LF_E_SERVER", "Server error.", CategoryNetwork, true},
+	ErrServerTimeModified: {"LF_E_SERVER_TIME_MODIFIED", "System time on server has been tampered with. " +
+		"Ensure your date and time settings are correct on the server machine.", CategoryTime, false},
+	ErrServerLicenseNotActivated:       {"LF_E_SERVER_LICENSE_NOT_ACTIVATED", "The server has not been activated using a license key.", CategoryServerLicense, false},
+	ErrServerLicenseExpired:            {"LF_E_SERVER_LICENSE_EXPIRED", "The server license has expired.", CategoryServerLicense, false},
+	ErrServerLicenseSuspended:          {"LF_E_SERVER_LICENSE_SUSPENDED", "The server license has been suspended.", CategoryServerLicense, false},
+	ErrServerLicenseGracePeriodOver:    {"LF_E_SERVER_LICENSE_GRACE_PERIOD_OVER", "The grace period for server license is over.", CategoryServerLicense, false},
+	ErrLeaseExceedsServerLicenseExpiry: {"LF_E_LEASE_EXCEEDS_SERVER_LICENSE_EXPIRY", "Requested offline lease duration exceeds server license expiry date.", CategoryQuota, false},
+}
+
+// String returns the name of the status code as defined in LexFloatStatusCodes.h,
+// e.g. "LF_E_INET".
+func (s StatusCode) String() string {
+	if info, ok := statusCodeInfos[s]; ok {
+		return info.name
+	}
+	return "StatusCode(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Message returns the human-readable message of the status code as defined in
+// LexFloatStatusCodes.h.
+func (s StatusCode) Message() string {
+	if info, ok := statusCodeInfos[s]; ok {
+		return info.message
+	}
+	return "Unknown status code."
+}
+
+// Category returns the category of the status code.
+func (s StatusCode) Category() Category {
+	if info, ok := statusCodeInfos[s]; ok {
+		return info.category
+	}
+	return CategoryUnknown
+}
+
+// Retryable reports whether the call that returned the status code may succeed
+// when retried later without any change in configuration, e.g. after a network
+// error or once a floating license has been freed on the server.
+func (s StatusCode) Retryable() bool {
+	return statusCodeInfos[s].retryable
+}
+
+// Message returns the human-readable message of a status code returned by one of
+// the int based functions of this package.
+//
+// Parameters:
+// - status: the status code
+func Message(status int) string {
+	return StatusCode(status).Message()
+}
+
+// CategoryOf returns the category of the StatusCode in err's chain.
+//
+// Returns: CategoryNone if err is nil, CategoryUnknown if err does not wrap a StatusCode
+func CategoryOf(err error) Category {
+	if err == nil {
+		return CategoryNone
+	}
+	var code StatusCode
+	if errors.As(err, &code) {
+		return code.Category()
+	}
+	return CategoryUnknown
+}
+
+// IsRetryable reports whether err wraps a StatusCode that is retryable.
+func IsRetryable(err error) bool {
+	var code StatusCode
+	if errors.As(err, &code) {
+		return code.Retryable()
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// statusConstants returns the LF_* constants declared in lexfloatstatus_codes.go.
+func statusConstants(t *testing.T) map[string]int {
+	file, err := parser.ParseFile(token.NewFileSet(), "lexfloatstatus_codes.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constants := make(map[string]int)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				literal, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok {
+					t.Fatalf("%s: value is not a literal", name.Name)
+				}
+				value, err := strconv.Atoi(literal.Value)
+				if err != nil {
+					t.Fatalf("%s: %v", name.Name, err)
+				}
+				constants[name.Name] = value
+			}
+		}
+	}
+	return constants
+}
+
+// headerStatusCodes returns the status codes declared in LexFloatStatusCodes.h.
+func headerStatusCodes(t *testing.T) map[string]int {
+	header, err := ioutil.ReadFile("lexfloatclient/LexFloatStatusCodes.h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codes := make(map[string]int)
+	for _, match := range regexp.MustCompile(`(?m)^\s*(LF_\w+)\s*=\s*(\d+)`).FindAllSubmatch(header, -1) {
+		value, _ := strconv.Atoi(string(match[2]))
+		codes[string(match[1])] = value
+	}
+	return codes
+}
+
+func TestStatusCodeInfosComplete(t *testing.T) {
+	constants := statusConstants(t)
+	if len(constants) == 0 {
+		t.Fatal("no LF_* constants found")
+	}
+	for name, value := range constants {
+		info, ok := statusCodeInfos[StatusCode(value)]
+		if !ok {
+			t.Errorf("%s (%d): missing from statusCodeInfos", name, value)
+			continue
+		}
+		if info.name != name {
+			t.Errorf("%s (%d): statusCodeInfos name is %s", name, value, info.name)
+		}
+		if info.message == "" {
+			t.Errorf("%s (%d): empty message", name, value)
+		}
+	}
+	if len(statusCodeInfos) != len(constants) {
+		t.Errorf("statusCodeInfos has %d entries, lexfloatstatus_codes.go declares %d constants", len(statusCodeInfos), len(constants))
+	}
+}
+
+func TestStatusConstantsMatchHeader(t *testing.T) {
+	constants := statusConstants(t)
+	codes := headerStatusCodes(t)
+	if len(codes) == 0 {
+		t.Fatal("no status codes found in LexFloatStatusCodes.h")
+	}
+	for name, value := range codes {
+		constant, ok := constants[name]
+		if !ok {
+			t.Errorf("%s (%d): missing from lexfloatstatus_codes.go", name, value)
+		} else if constant != value {
+			t.Errorf("%s: lexfloatstatus_codes.go has %d, header has %d", name, constant, value)
+		}
+	}
+}
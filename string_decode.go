@@ -0,0 +1,30 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import "unicode/utf16"
+
+// The library writes NUL-terminated strings into fixed-length buffers. These
+// helpers decode such a buffer up to the terminator, or the whole buffer when
+// it is completely filled. They do not depend on cgo so that they can be
+// tested on every platform.
+
+// decodeCString decodes a buffer of UTF-8 chars.
+func decodeCString(buffer []byte) string {
+	for i, char := range buffer {
+		if char == 0 {
+			return string(buffer[:i])
+		}
+	}
+	return string(buffer)
+}
+
+// decodeWCString decodes a buffer of UTF-16 wide chars.
+func decodeWCString(buffer []uint16) string {
+	for i, char := range buffer {
+		if char == 0 {
+			return string(utf16.Decode(buffer[:i]))
+		}
+	}
+	return string(utf16.Decode(buffer))
+}
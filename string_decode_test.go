@@ -0,0 +1,83 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+var stringDecodeTests = []struct {
+	name  string
+	value string
+}{
+	{"empty", ""},
+	{"ascii", "online"},
+	{"json", `[{"featureName":"seats","value":"10"}]`},
+	{"two-byte utf-8", "Grüße"},
+	{"three-byte utf-8", "日本語のメタデータ"},
+	{"four-byte utf-8", "rocket 🚀"},
+	{"max-length metadata value", strings.Repeat("é", maxMetadataValueLength)},
+}
+
+// cBuffer returns value encoded as UTF-8 in a buffer of the given length,
+// padded with NULs like the buffers filled by the library.
+func cBuffer(value string, length int) []byte {
+	buffer := make([]byte, length)
+	copy(buffer, value)
+	return buffer
+}
+
+// wcBuffer returns value encoded as UTF-16 in a buffer of the given length,
+// padded with NULs like the buffers filled by the library.
+func wcBuffer(value string, length int) []uint16 {
+	buffer := make([]uint16, length)
+	copy(buffer, utf16.Encode([]rune(value)))
+	return buffer
+}
+
+func TestDecodeCString(t *testing.T) {
+	for _, test := range stringDecodeTests {
+		t.Run(test.name, func(t *testing.T) {
+			encodedLength := len(test.value)
+			for _, length := range []int{encodedLength + 1, int(initialBufferLength) + encodedLength} {
+				if got := decodeCString(cBuffer(test.value, length)); got != test.value {
+					t.Errorf("buffer length %d: got %q, want %q", length, got, test.value)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeWCString(t *testing.T) {
+	for _, test := range stringDecodeTests {
+		t.Run(test.name, func(t *testing.T) {
+			encodedLength := len(utf16.Encode([]rune(test.value)))
+			for _, length := range []int{encodedLength + 1, int(initialBufferLength) + encodedLength} {
+				if got := decodeWCString(wcBuffer(test.value, length)); got != test.value {
+					t.Errorf("buffer length %d: got %q, want %q", length, got, test.value)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeFullBuffer(t *testing.T) {
+	value := strings.Repeat("x", int(initialBufferLength))
+	if got := decodeCString([]byte(value)); got != value {
+		t.Errorf("decodeCString: got %d bytes, want %d", len(got), len(value))
+	}
+	if got := decodeWCString(utf16.Encode([]rune(value))); got != value {
+		t.Errorf("decodeWCString: got %d chars, want %d", len(got), len(value))
+	}
+}
+
+func TestDecodeStopsAtFirstNUL(t *testing.T) {
+	if got := decodeCString([]byte("mode\x00stale data\x00")); got != "mode" {
+		t.Errorf("decodeCString: got %q, want %q", got, "mode")
+	}
+	if got := decodeWCString(utf16.Encode([]rune("mode\x00stale data\x00"))); got != "mode" {
+		t.Errorf("decodeWCString: got %q, want %q", got, "mode")
+	}
+}
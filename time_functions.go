@@ -0,0 +1,113 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// unixTime converts a timestamp returned by the library to a time.Time. The
+// zero timestamp means "never" and is converted to the zero time.
+func unixTime(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(timestamp, 0)
+}
+
+// ExpiryTime returns the expiry date of the feature entitlement, or the zero
+// time if it never expires.
+func (hostFeatureEntitlement HostFeatureEntitlement) ExpiryTime() time.Time {
+	return unixTime(hostFeatureEntitlement.ExpiresAt)
+}
+
+// MaxOfflineLease returns the maximum offline lease duration allowed by the
+// LexFloatServer.
+func (hostConfig HostConfig) MaxOfflineLease() time.Duration {
+	return time.Duration(hostConfig.MaxOfflineLeaseDuration) * time.Second
+}
+
+// HostLicenseExpiryTime returns the license expiry date of the LexFloatServer
+// license, or the zero time if it never expires.
+//
+// Errors: see HostLicenseExpiryDate
+func HostLicenseExpiryTime() (time.Time, error) {
+	expiryDate, err := HostLicenseExpiryDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return unixTime(int64(expiryDate)), nil
+}
+
+// FloatingClientLeaseExpiryTime returns the lease expiry date of the floating
+// client.
+//
+// Errors: see FloatingClientLeaseExpiryDate
+func FloatingClientLeaseExpiryTime() (time.Time, error) {
+	leaseExpiryDate, err := FloatingClientLeaseExpiryDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return unixTime(int64(leaseExpiryDate)), nil
+}
+
+// TimeUntilLeaseExpiry returns the time left until the lease of the floating
+// client expires. It is negative if the lease has already expired.
+//
+// Errors: see FloatingClientLeaseExpiryDate
+func TimeUntilLeaseExpiry() (time.Duration, error) {
+	leaseExpiry, err := FloatingClientLeaseExpiryTime()
+	if err != nil {
+		return 0, err
+	}
+	return timeUntil(leaseExpiry), nil
+}
+
+// maxOfflineLeaseSeconds is the longest offline lease duration in seconds that
+// fits the 32-bit unsigned int taken by the library on every platform.
+const maxOfflineLeaseSeconds = math.MaxUint32
+
+// offlineLeaseSeconds converts an offline lease duration to the whole seconds
+// expected by RequestOfflineFloatingLicense.
+func offlineLeaseSeconds(leaseDuration time.Duration) (uint, error) {
+	if leaseDuration < time.Second {
+		return 0, fmt.Errorf("lexfloatclient: offline lease duration %v is shorter than one second", leaseDuration)
+	}
+	seconds := int64(leaseDuration / time.Second)
+	if seconds > maxOfflineLeaseSeconds {
+		return 0, fmt.Errorf("lexfloatclient: offline lease duration %v is longer than %d seconds", leaseDuration, uint64(maxOfflineLeaseSeconds))
+	}
+	return uint(seconds), nil
+}
+
+// RequestOfflineFloatingLicenseFor sends the request to lease the license from
+// the LexFloatServer for offline usage. The lease duration is truncated to
+// whole seconds.
+//
+// Parameters:
+// - leaseDuration: the lease duration, between one second and math.MaxUint32 seconds
+//
+// Errors: an error if leaseDuration is shorter than one second or longer than
+// math.MaxUint32 seconds, or see RequestOfflineFloatingLicense
+func RequestOfflineFloatingLicenseFor(leaseDuration time.Duration) error {
+	seconds, err := offlineLeaseSeconds(leaseDuration)
+	if err != nil {
+		return err
+	}
+	return StatusError(RequestOfflineFloatingLicense(seconds))
+}
+
+// RequestOfflineFloatingLicenseForContext is like RequestOfflineFloatingLicenseFor
+// but honours the deadline and cancellation of ctx.
+//
+// Errors: ctx.Err() or see RequestOfflineFloatingLicenseFor
+func RequestOfflineFloatingLicenseForContext(ctx context.Context, leaseDuration time.Duration) error {
+	seconds, err := offlineLeaseSeconds(leaseDuration)
+	if err != nil {
+		return err
+	}
+	return RequestOfflineFloatingLicenseContext(ctx, seconds)
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cryptlex/lexfloatclient-go"
+	"github.com/cryptlex/lexfloatclient-go/lexfloattest"
+)
+
+func TestRequestOfflineFloatingLicenseFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "one second", duration: time.Second, want: time.Second},
+		{name: "truncated", duration: 90*time.Minute + 999*time.Millisecond, want: 90 * time.Minute},
+		{name: "zero", duration: 0, wantErr: true},
+		{name: "negative", duration: -time.Hour, wantErr: true},
+		{name: "shorter than one second", duration: 999 * time.Millisecond, wantErr: true},
+		{name: "longer than MaxUint32 seconds", duration: (math.MaxUint32 + 1) * time.Second, wantErr: true},
+		{name: "max duration", duration: math.MaxInt64, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now().Truncate(time.Second)
+			server := lexfloattest.NewServer(lexfloattest.Config{Start: start, MaxOfflineLeaseDuration: 24 * time.Hour})
+			useBackend(t, server, server.NewBackend())
+			for name, request := range map[string]func(time.Duration) error{
+				"RequestOfflineFloatingLicenseFor": lexfloatclient.RequestOfflineFloatingLicenseFor,
+				"RequestOfflineFloatingLicenseForContext": func(duration time.Duration) error {
+					return lexfloatclient.RequestOfflineFloatingLicenseForContext(context.Background(), duration)
+				},
+			} {
+				calls := server.Calls(lexfloattest.OpRequestOfflineFloatingLicense)
+				err := request(test.duration)
+				if test.wantErr {
+					if err == nil || server.Calls(lexfloattest.OpRequestOfflineFloatingLicense) != calls {
+						t.Errorf("%s(%v) = %v, want an error without a request", name, test.duration, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%s(%v): %v", name, test.duration, err)
+				}
+				if remaining, err := lexfloatclient.TimeUntilLeaseExpiry(); err != nil || remaining != test.want {
+					t.Errorf("%s(%v): TimeUntilLeaseExpiry = %v, %v, want %v", name, test.duration, remaining, err, test.want)
+				}
+				if err := lexfloatclient.DropFloatingLicenseContext(context.Background()); err != nil {
+					t.Fatalf("DropFloatingLicenseContext: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestExpiryTimes(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	server := leasedServer(t, lexfloattest.Config{Start: start, LeaseDuration: time.Hour})
+	if expiry, err := lexfloatclient.HostLicenseExpiryTime(); err != nil || !expiry.IsZero() {
+		t.Errorf("HostLicenseExpiryTime = %v, %v, want the zero time for a license that never expires", expiry, err)
+	}
+	server.SetLicenseExpiry(start.Add(48 * time.Hour))
+	if expiry, err := lexfloatclient.HostLicenseExpiryTime(); err != nil || !expiry.Equal(start.Add(48*time.Hour)) {
+		t.Errorf("HostLicenseExpiryTime = %v, %v, want %v", expiry, err, start.Add(48*time.Hour))
+	}
+	if expiry, err := lexfloatclient.FloatingClientLeaseExpiryTime(); err != nil || !expiry.Equal(start.Add(time.Hour)) {
+		t.Errorf("FloatingClientLeaseExpiryTime = %v, %v, want %v", expiry, err, start.Add(time.Hour))
+	}
+	// The lease is renewed halfway through and the remaining time follows the
+	// virtual clock.
+	server.Advance(40 * time.Minute)
+	if remaining, err := lexfloatclient.TimeUntilLeaseExpiry(); err != nil || remaining != 50*time.Minute {
+		t.Errorf("TimeUntilLeaseExpiry = %v, %v, want 50m", remaining, err)
+	}
+	if err := lexfloatclient.DropFloatingLicenseContext(context.Background()); err != nil {
+		t.Fatalf("DropFloatingLicenseContext: %v", err)
+	}
+	if _, err := lexfloatclient.TimeUntilLeaseExpiry(); !errors.Is(err, lexfloatclient.ErrNoLicense) {
+		t.Errorf("without a lease: TimeUntilLeaseExpiry = %v, want ErrNoLicense", err)
+	}
+}
+
+func TestTimeConversions(t *testing.T) {
+	if expiry := (lexfloatclient.HostFeatureEntitlement{}).ExpiryTime(); !expiry.IsZero() {
+		t.Errorf("ExpiryTime without an expiry date = %v, want the zero time", expiry)
+	}
+	if expiry := (lexfloatclient.HostFeatureEntitlement{ExpiresAt: 1700000000}).ExpiryTime(); !expiry.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ExpiryTime = %v, want %v", expiry, time.Unix(1700000000, 0))
+	}
+	if got := (lexfloatclient.HostConfig{MaxOfflineLeaseDuration: 86400}).MaxOfflineLease(); got != 24*time.Hour {
+		t.Errorf("MaxOfflineLease = %v, want 24h", got)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2026 Cryptlex LLP. All rights reserved.
+
+package lexfloatclient
+
+// The functions in this file mirror the getters in lexfloatclient.go but
+// return the value together with an error instead of writing through an
+// out-pointer and returning a status code. The returned error is always
+// nil or a StatusCode.
+
+// FloatingClientLibraryVersion returns the version of this library.
+//
+// Errors: LF_E_BUFFER_SIZE
+func FloatingClientLibraryVersion() (string, error) {
+	var libraryVersion string
+	status := GetFloatingClientLibraryVersion(&libraryVersion)
+	return libraryVersion, StatusError(status)
+}
+
+// HostProductVersionName returns the product version name.
+//
+// Deprecated: This function is deprecated. Use HostLicenseEntitlementSetName() instead.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_BUFFER_SIZE
+func HostProductVersionName() (string, error) {
+	var name string
+	status := GetHostProductVersionName(&name)
+	return name, StatusError(status)
+}
+
+// HostProductVersionDisplayName returns the product version display name.
+//
+// Deprecated: This function is deprecated. Use HostLicenseEntitlementSetDisplayName() instead.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_BUFFER_SIZE
+func HostProductVersionDisplayName() (string, error) {
+	var displayName string
+	status := GetHostProductVersionDisplayName(&displayName)
+	return displayName, StatusError(status)
+}
+
+// HostProductVersionFeatureFlag returns whether the product version feature flag
+// is enabled and its data.
+//
+// Deprecated: This function is deprecated. Use HostFeatureEntitlementByName() instead.
+//
+// Parameters:
+// - name: name of the feature flag
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_PRODUCT_VERSION_NOT_LINKED, LF_E_FEATURE_FLAG_NOT_FOUND, LF_E_BUFFER_SIZE
+func HostProductVersionFeatureFlag(name string) (bool, string, error) {
+	var enabled bool
+	var data string
+	status := GetHostProductVersionFeatureFlag(name, &enabled, &data)
+	return enabled, data, StatusError(status)
+}
+
+// HostLicenseEntitlementSetName returns the name of the entitlement set associated with the LexFloatServer license.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_ENTITLEMENT_SET_NOT_LINKED
+func HostLicenseEntitlementSetName() (string, error) {
+	var name string
+	status := GetHostLicenseEntitlementSetName(&name)
+	return name, StatusError(status)
+}
+
+// HostLicenseEntitlementSetDisplayName returns the display name of the entitlement set associated with the LexFloatServer license.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_ENTITLEMENT_SET_NOT_LINKED
+func HostLicenseEntitlementSetDisplayName() (string, error) {
+	var displayName string
+	status := GetHostLicenseEntitlementSetDisplayName(&displayName)
+	return displayName, StatusError(status)
+}
+
+// HostLicenseEntitlementSetTier returns the tier of the entitlement set associated with the LexFloatServer license.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_ENTITLEMENT_SET_NOT_LINKED
+func HostLicenseEntitlementSetTier() (int64, error) {
+	var tier int64
+	status := GetHostLicenseEntitlementSetTier(&tier)
+	return tier, StatusError(status)
+}
+
+// HostFeatureEntitlements returns the feature entitlements associated with the LexFloatServer license.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, *DecodeError
+func HostFeatureEntitlements() ([]HostFeatureEntitlement, error) {
+	var hostFeatureEntitlements []HostFeatureEntitlement
+	status, err := getJSON("GetHostFeatureEntitlements", currentBackend().GetHostFeatureEntitlementsInternal, &hostFeatureEntitlements)
+	if err != nil {
+		return nil, err
+	}
+	if status != LF_OK {
+		return nil, StatusError(status)
+	}
+	return hostFeatureEntitlements, nil
+}
+
+// HostFeatureEntitlementByName returns the feature entitlement with the given name
+// associated with the LexFloatServer license.
+//
+// Parameters:
+// - name: name of the feature
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_FEATURE_ENTITLEMENT_NOT_FOUND, *DecodeError
+func HostFeatureEntitlementByName(name string) (HostFeatureEntitlement, error) {
+	var hostFeatureEntitlement HostFeatureEntitlement
+	status, err := getHostFeatureEntitlement(name, &hostFeatureEntitlement)
+	if err != nil {
+		return HostFeatureEntitlement{}, err
+	}
+	if status != LF_OK {
+		return HostFeatureEntitlement{}, StatusError(status)
+	}
+	return hostFeatureEntitlement, nil
+}
+
+// HostProductMetadata returns the value of the product metadata.
+//
+// Parameters:
+// - key: key of the metadata field whose value you want to get
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
+func HostProductMetadata(key string) (string, error) {
+	var value string
+	status := GetHostProductMetadata(key, &value)
+	return value, StatusError(status)
+}
+
+// HostLicenseMetadata returns the value of the license metadata field associated with the LexFloatServer license.
+//
+// Parameters:
+// - key: key of the metadata field whose value you want to get
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
+func HostLicenseMetadata(key string) (string, error) {
+	var value string
+	status := GetHostLicenseMetadata(key, &value)
+	return value, StatusError(status)
+}
+
+// MeterAttribute holds the uses of a license meter attribute.
+type MeterAttribute struct {
+	// AllowedUses is the number of allowed uses. A value of -1 indicates unlimited allowed uses.
+	AllowedUses int64
+	TotalUses   uint64
+	GrossUses   uint64
+}
+
+// HostLicenseMeterAttribute returns the license meter attribute allowed uses, total uses and
+// gross uses associated with the LexFloatServer license.
+//
+// Parameters:
+// - name: name of the meter attribute
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_METER_ATTRIBUTE_NOT_FOUND
+func HostLicenseMeterAttribute(name string) (MeterAttribute, error) {
+	var meterAttribute MeterAttribute
+	status := GetHostLicenseMeterAttribute(name, &meterAttribute.AllowedUses, &meterAttribute.TotalUses, &meterAttribute.GrossUses)
+	if status != LF_OK {
+		return MeterAttribute{}, StatusError(status)
+	}
+	return meterAttribute, nil
+}
+
+// HostLicenseExpiryDate returns the license expiry date timestamp of the LexFloatServer license.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE
+func HostLicenseExpiryDate() (uint, error) {
+	var expiryDate uint
+	status := GetHostLicenseExpiryDate(&expiryDate)
+	return expiryDate, StatusError(status)
+}
+
+// FloatingClientMeterAttributeUses returns the meter attribute uses consumed by the floating client.
+//
+// Parameters:
+// - name: name of the meter attribute
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_METER_ATTRIBUTE_NOT_FOUND
+func FloatingClientMeterAttributeUses(name string) (uint, error) {
+	var uses uint
+	status := GetFloatingClientMeterAttributeUses(name, &uses)
+	return uses, StatusError(status)
+}
+
+// FloatingClientMetadata returns the value of the floating client metadata.
+//
+// Parameters:
+// - key: key of the metadata field whose value you want to retrieve
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE, LF_E_METADATA_KEY_NOT_FOUND
+func FloatingClientMetadata(key string) (string, error) {
+	var value string
+	status := GetFloatingClientMetadata(key, &value)
+	return value, StatusError(status)
+}
+
+// FloatingClientLeaseExpiryDate returns the lease expiry date timestamp of the floating client.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE
+func FloatingClientLeaseExpiryDate() (uint, error) {
+	var leaseExpiryDate uint
+	status := GetFloatingClientLeaseExpiryDate(&leaseExpiryDate)
+	return leaseExpiryDate, StatusError(status)
+}
+
+// FetchHostConfig fetches the host configuration.
+//
+// This function sends a network request to LexFloatServer to get the configuration details.
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_HOST_URL, LF_E_BUFFER_SIZE, LF_E_INET, LF_E_CLIENT, LF_E_IP, LF_E_SERVER, *DecodeError
+func FetchHostConfig() (HostConfig, error) {
+	var hostConfig HostConfig
+	status, err := getJSON("GetHostConfig", currentBackend().GetHostConfigInternal, &hostConfig)
+	if err != nil {
+		return HostConfig{}, err
+	}
+	if status != LF_OK {
+		return HostConfig{}, StatusError(status)
+	}
+	return hostConfig, nil
+}
+
+// FloatingLicenseMode returns the mode of the floating license (online or offline).
+//
+// Errors: LF_E_PRODUCT_ID, LF_E_NO_LICENSE, LF_E_BUFFER_SIZE
+func FloatingLicenseMode() (LeaseMode, error) {
+	var mode string
+	status := GetFloatingLicenseMode(&mode)
+	return LeaseMode(mode), StatusError(status)
+}
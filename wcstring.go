@@ -4,16 +4,12 @@ package lexfloatclient
 
 import "C"
 import (
-	"bytes"
-	"encoding/binary"
 	"unicode/utf16"
 	"unsafe"
 )
 
-const (
-	maxCArrayLength  C.uint = 4096
-	maxGoArrayLength C.int  = 4096
-)
+// cChar is the character type of the string buffers of the library.
+type cChar = C.ushort
 
 func goToCString(goString string) *C.ushort {
 	bytes := []rune(goString)
@@ -24,25 +20,14 @@ func goToCString(goString string) *C.ushort {
 	return cString
 }
 
-func ctoGoString(cString *C.ushort) string {
-	encodedBytes := C.GoBytes(unsafe.Pointer(cString), maxGoArrayLength)
-	goString, _ := decodeUtf16(encodedBytes, binary.LittleEndian)
-	return goString
-}
-
-func getCArray() [maxCArrayLength]C.ushort {
-	var cArray [maxCArrayLength]C.ushort
-	return cArray
+func ctoGoString(cArray []C.ushort) string {
+	encodedChars := make([]uint16, len(cArray))
+	for i, char := range cArray {
+		encodedChars[i] = uint16(char)
+	}
+	return decodeWCString(encodedChars)
 }
 
 func freeCString(cString *C.ushort) {
 	// do nothing
 }
-
-func decodeUtf16(b []byte, order binary.ByteOrder) (string, error) {
-	ints := make([]uint16, len(b)/2)
-	if err := binary.Read(bytes.NewReader(b), order, &ints); err != nil {
-		return "", err
-	}
-	return string(utf16.Decode(ints)), nil
-}